@@ -1,10 +1,10 @@
 package xrpl
 
 import (
+	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
-	"log"
-	"math"
 	"net/http"
 	"strconv"
 	"sync"
@@ -14,27 +14,116 @@ import (
 )
 
 type ClientConfig struct {
-	URL                string
-	Authorization      string
-	Certificate        string
-	FeeCushion         uint32
-	Key                string
-	MaxFeeXRP          uint64
-	Passphrase         byte
-	Proxy              byte
-	ProxyAuthorization byte
-	ReadTimeout        time.Duration
-	WriteTimeout       time.Duration
-	HeartbeatInterval  time.Duration
-	QueueCapacity      int
+	URL string
+
+	// Credentials in "username:password" form, sent as HTTP basic auth in
+	// the websocket handshake
+	Authorization string
+
+	// PEM encoded client certificate and private key, or paths to PEM files,
+	// for mutual TLS. A PEM encrypted key is decrypted with Passphrase.
+	Certificate string
+	Key         string
+	Passphrase  string
+
+	// PEM encoded CA certificates, or paths to PEM files, used instead of the
+	// system roots to verify the server
+	TrustedCertificates []string
+
+	// Base TLS configuration. The settings above are applied on top of it.
+	TLSConfig *tls.Config
+
+	// Proxy URL, either http://host:port or socks5://host:port. Defaults to
+	// the proxy set in the environment.
+	Proxy string
+
+	// Proxy credentials in "username:password" form
+	ProxyAuthorization string
+
+	FeeCushion uint32
+	MaxFeeXRP  uint64
+
+	// How long the connection may stay silent before reads fail. Each pong
+	// extends the read deadline by ReadTimeout. Must be longer than
+	// HeartbeatInterval. Default: 20s
+	//
+	// Values below 1ms are taken as seconds, as earlier versions expected,
+	// and a warning is logged.
+	ReadTimeout time.Duration
+
+	// Deadline for writing a single message to the connection. Values below
+	// 1ms are taken as seconds. Default: 20s
+	WriteTimeout time.Duration
+
+	// Time between heartbeats. Values below 1ms are taken as seconds.
+	// Default: 5s
+	HeartbeatInterval time.Duration
+
+	QueueCapacity int
+
+	// How heartbeats are sent. Default: HeartbeatPing
+	HeartbeatMode HeartbeatMode
+
+	// Consecutive heartbeats without a response after which the connection
+	// is considered dead and the client reconnects. Default: 3
+	HeartbeatMaxMissed int
+
+	// Re-send idempotent read-only requests (account_info, ledger, tx etc.)
+	// that were in flight when the connection dropped, once Reconnect has
+	// established a new connection. Other requests always fail with
+	// ErrConnectionLost.
+	ResendOnReconnect bool
+
+	// What to do with stream messages when a stream channel is full, per
+	// stream type (StreamTypeLedger etc., or "default" for StreamDefault).
+	// Streams without an entry use StreamOverflowPolicy. Default: OverflowBlock
+	StreamOverflowPolicy   OverflowPolicy
+	StreamOverflowPolicies map[string]OverflowPolicy
+
+	// Backoff policy for re-establishing dropped connections
+	Reconnect ReconnectPolicy
+
+	// Destination of log output. Default: warnings and errors are written to
+	// the standard library logger.
+	Logger Logger
+
+	// Wrap every request and its response, e.g. to add api_version, log,
+	// trace or rate limit requests. See Interceptor.
+	Interceptors []Interceptor
+
+	// Wrap every stream message before it is delivered. See
+	// StreamInterceptor.
+	StreamInterceptors []StreamInterceptor
+
+	// Don't connect until Connect is called or the first request is sent
+	LazyConnect bool
+
+	// Called on every connection state transition. The callback runs on the
+	// goroutine that caused the transition and must not block.
+	OnStateChange func(ConnectionState)
 }
 
 type Client struct {
 	config              ClientConfig
+	log                 Logger
+	errs                chan error
+	dialer              *websocket.Dialer
+	header              http.Header
 	connection          *websocket.Conn
-	heartbeatDone       chan bool
-	closed              bool
+	heartbeatDone       chan struct{}
+	rtt                 rttStats
+	state               ConnectionState
+	shutdown            chan struct{}
+	shutdownOnce        sync.Once
+	closeOnce           sync.Once
+	connectOnce         sync.Once
+	done                chan struct{}
+	goroutines          sync.WaitGroup
+	spawnMutex          sync.Mutex
+	stopping            bool
 	mutex               sync.Mutex
+	reconnecting        bool
+	reconnectAgain      bool
 	response            *http.Response
 	StreamLedger        chan []byte
 	StreamTransaction   chan []byte
@@ -44,9 +133,16 @@ type Client struct {
 	StreamConsensus     chan []byte
 	StreamPathFind      chan []byte
 	StreamServer        chan []byte
+	StreamBookChanges   chan []byte
 	StreamDefault       chan []byte
 	StreamSubscriptions map[string]bool
-	requestQueue        map[string](chan<- BaseResponse)
+	subscriptions       subscriptions
+	streams             map[string]*streamQueue
+	handlers            map[string][]*typedHandlers
+	handlerMutex        sync.RWMutex
+	invoke              Invoker
+	deliver             StreamHandler
+	requestQueue        map[string]*pendingRequest
 	nextId              int
 	err                 error
 }
@@ -56,46 +152,122 @@ func (config *ClientConfig) Validate() error {
 		return errors.New("cannot create a new connection with an empty URL")
 	}
 
-	if config.ReadTimeout < 0 ||
-		config.ReadTimeout <= config.HeartbeatInterval ||
-		config.ReadTimeout >= math.MaxInt32 {
-		return fmt.Errorf("connection read timeout out of bounds: %d", config.ReadTimeout)
+	if config.HeartbeatInterval <= 0 {
+		return fmt.Errorf("heartbeat interval must be positive: %s", config.HeartbeatInterval)
+	}
+	if config.ReadTimeout <= config.HeartbeatInterval {
+		return fmt.Errorf("read timeout (%s) must be longer than the heartbeat interval (%s)", config.ReadTimeout, config.HeartbeatInterval)
+	}
+	if config.WriteTimeout <= 0 {
+		return fmt.Errorf("write timeout must be positive: %s", config.WriteTimeout)
 	}
-	if config.WriteTimeout < 0 ||
-		config.WriteTimeout <= config.HeartbeatInterval ||
-		config.WriteTimeout >= math.MaxInt32 {
-		return fmt.Errorf("connection write timeout out of bounds: %d", config.WriteTimeout)
+	if config.QueueCapacity < 0 {
+		return fmt.Errorf("queue capacity out of bounds: %d", config.QueueCapacity)
 	}
-	if config.HeartbeatInterval < 0 ||
-		config.HeartbeatInterval >= math.MaxInt32 {
-		return fmt.Errorf("connection heartbeat interval out of bounds: %d", config.HeartbeatInterval)
+	if !config.HeartbeatMode.valid() {
+		return fmt.Errorf("unknown heartbeat mode: %q", config.HeartbeatMode)
+	}
+	if config.HeartbeatMaxMissed < 1 {
+		return fmt.Errorf("heartbeat max missed out of bounds: %d", config.HeartbeatMaxMissed)
+	}
+	if err := config.Reconnect.validate(); err != nil {
+		return err
+	}
+	if !config.StreamOverflowPolicy.valid() {
+		return fmt.Errorf("unknown stream overflow policy: %q", config.StreamOverflowPolicy)
+	}
+	for streamType, policy := range config.StreamOverflowPolicies {
+		if !policy.valid() {
+			return fmt.Errorf("unknown stream overflow policy for %s: %q", streamType, policy)
+		}
 	}
 
 	return nil
 }
 
+// NewClient creates a client and connects it to config.URL. It panics if
+// config is invalid. If the connection cannot be established, the error is
+// reported on Errors() and the client keeps trying in the background. Use
+// Dial to get configuration and connection errors returned instead.
 func NewClient(config ClientConfig) *Client {
+	client, err := newClient(config)
+	if err != nil {
+		panic(err)
+	}
+	if !config.LazyConnect {
+		client.Connect(context.Background())
+	}
+	return client
+}
+
+// migrateDurations converts timeouts given as a plain number of seconds, as
+// earlier versions expected, to durations.
+func (config *ClientConfig) migrateDurations() {
+	fields := []struct {
+		name  string
+		value *time.Duration
+	}{
+		{"ReadTimeout", &config.ReadTimeout},
+		{"WriteTimeout", &config.WriteTimeout},
+		{"HeartbeatInterval", &config.HeartbeatInterval},
+	}
+	for _, f := range fields {
+		if *f.value > 0 && *f.value < time.Millisecond {
+			seconds := int64(*f.value)
+			*f.value = time.Duration(seconds) * time.Second
+			config.Logger.Warn("ClientConfig duration given as a number of seconds, use a time.Duration instead", "field", f.name, "seconds", seconds)
+		}
+	}
+}
+
+func newClient(config ClientConfig) (*Client, error) {
+	if config.Logger == nil {
+		config.Logger = stdLogger{}
+	}
+	config.migrateDurations()
+
 	if config.ReadTimeout == 0 {
-		config.ReadTimeout = 20
+		config.ReadTimeout = 20 * time.Second
 	}
 	if config.WriteTimeout == 0 {
-		config.WriteTimeout = 20
+		config.WriteTimeout = 20 * time.Second
 	}
 	if config.HeartbeatInterval == 0 {
-		config.HeartbeatInterval = 5
+		config.HeartbeatInterval = 5 * time.Second
+	}
+	if config.HeartbeatMode == "" {
+		config.HeartbeatMode = HeartbeatPing
+	}
+	if config.HeartbeatMaxMissed == 0 {
+		config.HeartbeatMaxMissed = 3
 	}
 
 	if config.QueueCapacity == 0 {
 		config.QueueCapacity = 128
 	}
+	config.Reconnect.setDefaults()
+	if config.StreamOverflowPolicy == "" {
+		config.StreamOverflowPolicy = OverflowBlock
+	}
 
 	if err := config.Validate(); err != nil {
-		panic(err)
+		return nil, err
+	}
+	dialer, header, err := config.newDialer()
+	if err != nil {
+		return nil, err
 	}
 
+	streamSubscriptions := make(map[string]bool)
 	client := &Client{
 		config:              config,
-		heartbeatDone:       make(chan bool),
+		log:                 config.Logger,
+		errs:                make(chan error, config.QueueCapacity),
+		dialer:              dialer,
+		header:              header,
+		state:               StateNew,
+		shutdown:            make(chan struct{}),
+		done:                make(chan struct{}),
 		StreamLedger:        make(chan []byte, config.QueueCapacity),
 		StreamTransaction:   make(chan []byte, config.QueueCapacity),
 		StreamValidation:    make(chan []byte, config.QueueCapacity),
@@ -104,74 +276,126 @@ func NewClient(config ClientConfig) *Client {
 		StreamConsensus:     make(chan []byte, config.QueueCapacity),
 		StreamPathFind:      make(chan []byte, config.QueueCapacity),
 		StreamServer:        make(chan []byte, config.QueueCapacity),
+		StreamBookChanges:   make(chan []byte, config.QueueCapacity),
 		StreamDefault:       make(chan []byte, config.QueueCapacity),
-		StreamSubscriptions: make(map[string]bool),
-		requestQueue:        make(map[string](chan<- BaseResponse)),
+		StreamSubscriptions: streamSubscriptions,
+		subscriptions:       newSubscriptions(streamSubscriptions),
+		handlers:            make(map[string][]*typedHandlers),
+		requestQueue:        make(map[string]*pendingRequest),
 		nextId:              0,
 	}
 
-	_, err := client.NewConnection()
-	if err != nil {
-		log.Println("WS connection error:", client.config.URL, err)
+	client.newStreamQueues()
+	client.invoke = chainInterceptors(config.Interceptors, client.roundTrip)
+	client.deliver = chainStreamInterceptors(config.StreamInterceptors, client.deliverStream)
+	return client, nil
+}
+
+// Connect establishes the first connection of a client created with
+// LazyConnect. If it fails, the error is returned and the client keeps trying
+// in the background. Connect does nothing if the client has been connected
+// before, and returns ErrClientClosed once the client is closed. Requests on
+// a lazy client call it implicitly.
+func (c *Client) Connect(ctx context.Context) error {
+	var err error
+	c.connectOnce.Do(func() { err = c.connect(ctx, true) })
+	if err == nil {
+		if state := c.State(); state == StateClosing || state == StateClosed {
+			return ErrClientClosed
+		}
 	}
-	return client
+	return err
+}
+
+func (c *Client) connect(ctx context.Context, retry bool) error {
+	if !c.setState(StateConnecting) {
+		switch c.State() {
+		case StateOpen, StateReconnecting:
+			// Connected by Reconnect in the meantime
+			return nil
+		}
+		return ErrClientClosed
+	}
+	_, err := c.newConnection(ctx)
+	if err != nil && retry {
+		c.reportError("websocket connection failed", err)
+		// Keep trying in the background
+		c.spawn(func() { c.reconnect(1) })
+	}
+	return err
 }
 
 func (c *Client) NewConnection() (*websocket.Conn, error) {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
+	return c.newConnection(context.Background())
+}
+
+func (c *Client) newConnection(ctx context.Context) (*websocket.Conn, error) {
+	select {
+	case <-c.shutdown:
+		return nil, ErrClientClosed
+	default:
+	}
 
-	conn, r, err := websocket.DefaultDialer.Dial(c.config.URL, nil)
+	conn, r, err := c.dialer.DialContext(ctx, c.config.URL, c.header)
+	c.mutex.Lock()
 	if err != nil {
 		c.err = err
+		c.mutex.Unlock()
 		return nil, err
 	}
 	defer r.Body.Close()
+	select {
+	case <-c.shutdown:
+		// Close was called while dialing
+		c.mutex.Unlock()
+		conn.Close()
+		return nil, ErrClientClosed
+	default:
+	}
 	c.connection = conn
 	c.response = r
-	c.closed = false
+	c.heartbeatDone = make(chan struct{})
+	c.rtt.reset()
 
 	// Set connection handlers and heartbeat
-	c.connection.SetPongHandler(c.handlePong)
-	go c.handleResponse()
-	go c.heartbeat()
-	return c.connection, nil
-}
-
-func (c *Client) Reconnect() error {
-	// Close old websocket connection
-	c.Close()
-
-	// Create a new websocket connection
-	_, err := c.NewConnection()
-	if err != nil {
-		log.Println("WS reconnection error:", c.config.URL, err)
-		return err
-	}
+	conn.SetPongHandler(func(message string) error {
+		return c.handlePong(conn, message)
+	})
+	heartbeatDone := c.heartbeatDone
+	c.spawn(func() { c.handleResponse(conn) })
+	c.spawn(func() { c.heartbeat(conn, heartbeatDone) })
+	c.mutex.Unlock()
 
-	// Re-subscribe xrpl streams
-	_, err = c.Subscribe(c.Subscriptions())
-	if err != nil {
-		log.Println("WS stream subscription error:", err)
-	}
-	return nil
+	c.log.Info("websocket connected", "url", c.config.URL)
+	c.setState(StateOpen)
+	return conn, nil
 }
 
 func (c *Client) Ping(message []byte) error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
-	// log.Println("PING:", string(message))
-	if err := c.connection.WriteMessage(websocket.PingMessage, message); err != nil {
+	if c.connection == nil {
+		return ErrNotConnected
+	}
+	if err := c.writeMessage(c.connection, websocket.PingMessage, message); err != nil {
 		return err
 	}
 	return nil
 }
 
+// writeMessage writes a message to conn, giving up after WriteTimeout. A
+// timed out write leaves the connection unusable; the heartbeat then detects
+// it as dead.
+func (c *Client) writeMessage(conn *websocket.Conn, messageType int, data []byte) error {
+	conn.SetWriteDeadline(time.Now().Add(c.config.WriteTimeout))
+	return conn.WriteMessage(messageType, data)
+}
+
 // Returns incremental ID that may be used as request ID for websocket requests
 func (c *Client) NextID() string {
 	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	c.nextId++
-	c.mutex.Unlock()
 	return strconv.Itoa(c.nextId)
 }
 
@@ -185,20 +409,114 @@ func (c *Client) Subscriptions() []string {
 	return subs
 }
 
+// Close the client. Requests already in flight are given up to ReadTimeout
+// to complete; requests still pending after that fail with
+// ErrConnectionLost, and new requests fail with ErrClientClosed. Close returns
+// once all of the client's goroutines have stopped. It is safe to call Close
+// more than once and from several goroutines, but not from a stream handler
+// or OnStateChange callback, which Close would wait for.
 func (c *Client) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.ReadTimeout)
+	defer cancel()
+	err := c.CloseContext(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil
+	}
+	return err
+}
+
+// CloseContext is like Close but waits for in-flight requests until ctx is
+// done. If requests had to be abandoned, ctx.Err() is returned.
+func (c *Client) CloseContext(ctx context.Context) error {
+	first := false
+	c.closeOnce.Do(func() { first = true })
+	if !first {
+		<-c.done
+		return nil
+	}
+
+	c.setState(StateClosing)
+	drainErr := c.drainPendingRequests(ctx)
+
+	c.shutdownOnce.Do(func() { close(c.shutdown) })
+	err := c.close()
+	c.failPendingRequests(false)
+
+	c.spawnMutex.Lock()
+	c.stopping = true
+	c.spawnMutex.Unlock()
+	c.goroutines.Wait()
+
+	c.setState(StateClosed)
+	close(c.done)
+	if err != nil {
+		return err
+	}
+	return drainErr
+}
+
+// Done returns a channel that is closed once the client is closed and all of
+// its goroutines have stopped, whether by Close or because reconnection was
+// abandoned.
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// drainPendingRequests waits until no request is waiting for a response.
+func (c *Client) drainPendingRequests(ctx context.Context) error {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		c.mutex.Lock()
+		pending := len(c.requestQueue)
+		c.mutex.Unlock()
+		if pending == 0 {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			c.log.Warn("closing with pending requests", "url", c.config.URL, "pending", pending)
+			return ctx.Err()
+		}
+	}
+}
+
+// spawn runs fn on a new goroutine that Close waits for. Nothing is started
+// once Close is waiting.
+func (c *Client) spawn(fn func()) bool {
+	c.spawnMutex.Lock()
+	defer c.spawnMutex.Unlock()
+	if c.stopping {
+		return false
+	}
+	c.goroutines.Add(1)
+	go func() {
+		defer c.goroutines.Done()
+		fn()
+	}()
+	return true
+}
+
+func (c *Client) close() error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
-	c.closed = true
-	c.heartbeatDone <- true
+	if c.connection == nil {
+		return nil
+	}
+	close(c.heartbeatDone)
 
-	err := c.connection.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	conn := c.connection
+	c.connection = nil
+	err := c.writeMessage(conn, websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
 	if err != nil {
-		log.Println("WS write error: ", err)
+		c.log.Warn("websocket close message failed", "url", c.config.URL, "error", err)
+		conn.Close()
 		return err
 	}
-	err = c.connection.Close()
+	err = conn.Close()
 	if err != nil {
-		log.Println("WS close error: ", err)
+		c.log.Warn("websocket close failed", "url", c.config.URL, "error", err)
 		return err
 	}
 	return nil
@@ -0,0 +1,107 @@
+package xrpl
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testLogger records warnings.
+type testLogger struct {
+	mutex    sync.Mutex
+	warnings []string
+}
+
+func (l *testLogger) Debug(msg string, args ...any) {}
+func (l *testLogger) Info(msg string, args ...any)  {}
+func (l *testLogger) Error(msg string, args ...any) {}
+func (l *testLogger) Warn(msg string, args ...any) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.warnings = append(l.warnings, fmt.Sprint(msg, args))
+}
+
+func TestLegacyDurations(t *testing.T) {
+	logger := &testLogger{}
+	c, err := newClient(ClientConfig{
+		URL:               "ws://127.0.0.1:1",
+		ReadTimeout:       20,
+		WriteTimeout:      3,
+		HeartbeatInterval: 2,
+		Logger:            logger,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if c.config.ReadTimeout != 20*time.Second || c.config.WriteTimeout != 3*time.Second || c.config.HeartbeatInterval != 2*time.Second {
+		t.Errorf("durations = %s, %s, %s", c.config.ReadTimeout, c.config.WriteTimeout, c.config.HeartbeatInterval)
+	}
+	if len(logger.warnings) != 3 || !strings.Contains(logger.warnings[0], "ReadTimeout") {
+		t.Errorf("warnings = %q", logger.warnings)
+	}
+
+	// Real durations are left alone
+	logger = &testLogger{}
+	c, err = newClient(ClientConfig{URL: "ws://127.0.0.1:1", ReadTimeout: 30 * time.Second, Logger: logger})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if c.config.ReadTimeout != 30*time.Second || len(logger.warnings) != 0 {
+		t.Errorf("ReadTimeout = %s, warnings = %q", c.config.ReadTimeout, logger.warnings)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		config ClientConfig
+		err    string
+	}{
+		"empty url": {
+			ClientConfig{},
+			"empty URL",
+		},
+		"read timeout equal to heartbeat interval": {
+			ClientConfig{ReadTimeout: 5 * time.Second, HeartbeatInterval: 5 * time.Second},
+			"must be longer than the heartbeat interval",
+		},
+		"read timeout shorter than heartbeat interval": {
+			ClientConfig{ReadTimeout: time.Second},
+			"must be longer than the heartbeat interval",
+		},
+		"negative heartbeat interval": {
+			ClientConfig{HeartbeatInterval: -time.Second},
+			"heartbeat interval must be positive",
+		},
+		"negative write timeout": {
+			ClientConfig{WriteTimeout: -time.Second},
+			"write timeout must be positive",
+		},
+		"negative queue capacity": {
+			ClientConfig{QueueCapacity: -1},
+			"queue capacity out of bounds",
+		},
+		"unknown heartbeat mode": {
+			ClientConfig{HeartbeatMode: "carrier pigeon"},
+			"unknown heartbeat mode",
+		},
+	}
+	for name, test := range tests {
+		if name != "empty url" {
+			test.config.URL = "ws://127.0.0.1:1"
+		}
+		c, err := newClient(test.config)
+		if err == nil {
+			c.Close()
+			t.Errorf("%s: newClient succeeded", name)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: err = %v, want %q", name, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package xrpl_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/xrpscan/xrpl-go"
+	"github.com/xrpscan/xrpl-go/xrpltest"
+)
+
+var fastReconnect = xrpl.WithReconnectPolicy(xrpl.ReconnectPolicy{
+	InitialDelay: 10 * time.Millisecond,
+	MaxDelay:     10 * time.Millisecond,
+})
+
+// stateRecorder collects the state transitions of a client.
+type stateRecorder struct {
+	mutex  sync.Mutex
+	states []xrpl.ConnectionState
+}
+
+func (r *stateRecorder) record(state xrpl.ConnectionState) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.states = append(r.states, state)
+}
+
+func (r *stateRecorder) list() []xrpl.ConnectionState {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]xrpl.ConnectionState(nil), r.states...)
+}
+
+func assertClosed(t *testing.T, client *xrpl.Client) {
+	t.Helper()
+	select {
+	case <-client.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Done not closed")
+	}
+	if state := client.State(); state != xrpl.StateClosed {
+		t.Errorf("State() = %s, want %s", state, xrpl.StateClosed)
+	}
+	if _, err := client.Request(xrpl.BaseRequest{"command": "ping"}); !errors.Is(err, xrpl.ErrClientClosed) {
+		t.Errorf("request after Close: err = %v, want ErrClientClosed", err)
+	}
+}
+
+func TestCloseConcurrent(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+	var states stateRecorder
+	client, err := xrpl.Dial(context.Background(), srv.URL, xrpl.WithStateChange(states.record))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Close(); err != nil {
+				t.Errorf("Close: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	assertClosed(t, client)
+
+	want := []xrpl.ConnectionState{xrpl.StateConnecting, xrpl.StateOpen, xrpl.StateClosing, xrpl.StateClosed}
+	got := states.list()
+	if len(got) != len(want) {
+		t.Fatalf("states = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("states = %v, want %v", got, want)
+		}
+	}
+	waitFor(t, "server side disconnect", func() bool { return srv.Connections() == 0 })
+}
+
+func TestCloseNeverConnected(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+	client, err := xrpl.Dial(context.Background(), srv.URL, xrpl.WithLazyConnect())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state := client.State(); state != xrpl.StateNew {
+		t.Fatalf("State() = %s, want %s", state, xrpl.StateNew)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	assertClosed(t, client)
+	if err := client.Connect(context.Background()); !errors.Is(err, xrpl.ErrClientClosed) {
+		t.Errorf("Connect after Close: err = %v, want ErrClientClosed", err)
+	}
+	if n := srv.Connections(); n != 0 {
+		t.Errorf("closed client opened %d connections", n)
+	}
+}
+
+func TestCloseDuringReconnect(t *testing.T) {
+	srv := xrpltest.NewServer()
+	client, err := xrpl.Dial(context.Background(), srv.URL, fastReconnect)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// With the server gone, the client keeps redialing
+	srv.Close()
+	waitFor(t, "reconnecting", func() bool { return client.State() == xrpl.StateReconnecting })
+
+	closed := make(chan error, 1)
+	go func() { closed <- client.Close() }()
+	select {
+	case err := <-closed:
+		if err != nil {
+			t.Errorf("Close: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close blocked while reconnecting")
+	}
+	assertClosed(t, client)
+}
+
+func TestDoneAfterReconnectAbandoned(t *testing.T) {
+	srv := xrpltest.NewServer()
+	client, err := xrpl.Dial(context.Background(), srv.URL, xrpl.WithReconnectPolicy(xrpl.ReconnectPolicy{
+		InitialDelay: 10 * time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		MaxAttempts:  2,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-client.Done():
+		t.Fatal("Done closed while connected")
+	default:
+	}
+
+	srv.Close()
+	assertClosed(t, client)
+	if err := client.Close(); err != nil {
+		t.Errorf("Close after Done: %v", err)
+	}
+}
+
+func TestCloseDrainsPendingRequests(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+	srv.On("fee", map[string]interface{}{"current_ledger_size": "12"})
+	srv.SetCommandLatency("fee", 100*time.Millisecond)
+	client, err := xrpl.Dial(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := client.Request(xrpl.BaseRequest{"command": "fee"})
+		errs <- err
+	}()
+	waitFor(t, "fee request", func() bool { return len(srv.Requests()) == 1 })
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := <-errs; err != nil {
+		t.Errorf("in-flight request: %v", err)
+	}
+	assertClosed(t, client)
+}
+
+func TestCloseContextAbandonsPendingRequests(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+	srv.On("fee")
+	srv.SetCommandLatency("fee", 5*time.Second)
+	client, err := xrpl.Dial(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := client.Request(xrpl.BaseRequest{"command": "fee"})
+		errs <- err
+	}()
+	waitFor(t, "fee request", func() bool { return len(srv.Requests()) == 1 })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := client.CloseContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("CloseContext: err = %v, want context.DeadlineExceeded", err)
+	}
+	if err := <-errs; !errors.Is(err, xrpl.ErrConnectionLost) {
+		t.Errorf("abandoned request: err = %v, want ErrConnectionLost", err)
+	}
+	assertClosed(t, client)
+}
+
+// A connection that drops while subscriptions are being restored must be
+// replaced as well.
+func TestDisconnectDuringResubscribe(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+	var subscribes int32
+	srv.Handle("subscribe", func(xrpl.BaseRequest) (interface{}, error) {
+		if atomic.AddInt32(&subscribes, 1) == 2 {
+			srv.Disconnect()
+		}
+		return map[string]interface{}{}, nil
+	})
+	client, err := xrpl.Dial(context.Background(), srv.URL, fastReconnect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if _, err := client.Subscribe([]string{xrpl.StreamTypeLedger}); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	srv.Disconnect()
+
+	waitFor(t, "subscription restored", func() bool { return atomic.LoadInt32(&subscribes) == 3 })
+	waitFor(t, "reconnect", func() bool {
+		return client.State() == xrpl.StateOpen && srv.Connections() == 1
+	})
+	if _, err := client.Request(xrpl.BaseRequest{"command": "ping"}); err != nil {
+		t.Errorf("request after reconnect: %v", err)
+	}
+}
+
+func TestLazyConnectAfterReconnect(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+	client, err := xrpl.Dial(context.Background(), srv.URL, xrpl.WithLazyConnect())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if err := client.Reconnect(); err != nil {
+		t.Fatalf("Reconnect: %v", err)
+	}
+	if state := client.State(); state != xrpl.StateOpen {
+		t.Fatalf("State() = %s, want %s", state, xrpl.StateOpen)
+	}
+	if _, err := client.Request(xrpl.BaseRequest{"command": "ping"}); err != nil {
+		t.Errorf("first request: %v", err)
+	}
+	if err := client.Connect(context.Background()); err != nil {
+		t.Errorf("Connect: %v", err)
+	}
+	if n := srv.Connections(); n != 1 {
+		t.Errorf("%d connections, want 1", n)
+	}
+}
@@ -1,47 +1,32 @@
 package xrpl
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/gorilla/websocket"
 )
 
 func (c *Client) Subscribe(streams []string) (BaseResponse, error) {
-	req := BaseRequest{
-		"command": "subscribe",
-		"streams": streams,
-	}
-	res, err := c.Request(req)
-	if err != nil {
-		return nil, err
-	}
-
-	c.mutex.Lock()
-	for _, stream := range streams {
-		c.StreamSubscriptions[stream] = true
-	}
-	c.mutex.Unlock()
+	return c.SubscribeContext(context.Background(), streams)
+}
 
-	return res, nil
+// SubscribeContext is like Subscribe but gives up waiting for the response
+// when ctx is cancelled or its deadline expires.
+func (c *Client) SubscribeContext(ctx context.Context, streams []string) (BaseResponse, error) {
+	return c.SubscribeTo(ctx, Subscription{Streams: streams})
 }
 
 func (c *Client) Unsubscribe(streams []string) (BaseResponse, error) {
-	req := BaseRequest{
-		"command": "unsubscribe",
-		"streams": streams,
-	}
-	res, err := c.Request(req)
-	if err != nil {
-		return nil, err
-	}
-
-	c.mutex.Lock()
-	for _, stream := range streams {
-		delete(c.StreamSubscriptions, stream)
-	}
-	c.mutex.Unlock()
+	return c.UnsubscribeContext(context.Background(), streams)
+}
 
-	return res, nil
+// UnsubscribeContext is like Unsubscribe but gives up waiting for the response
+// when ctx is cancelled or its deadline expires.
+func (c *Client) UnsubscribeContext(ctx context.Context, streams []string) (BaseResponse, error) {
+	return c.UnsubscribeFrom(ctx, Subscription{Streams: streams})
 }
 
 // Send a websocket request. This method takes a BaseRequest object and automatically adds
@@ -55,8 +40,42 @@ func (c *Client) Unsubscribe(streams []string) (BaseResponse, error) {
 //		"ledger_index": "current",
 //	}
 //
-//	err := client.Request(req, func(){})
+//	res, err := client.Request(req)
 func (c *Client) Request(req BaseRequest) (BaseResponse, error) {
+	return c.RequestContext(context.Background(), req)
+}
+
+// Send a websocket request and wait for its response until ctx is done. The
+// request passes through ClientConfig.Interceptors. Error
+// responses from rippled are returned as *RPCError. If ctx
+// is cancelled or its deadline expires first, the pending request is dropped
+// and ctx.Err() is returned. An expired deadline is reported as an error that
+// matches both ErrRequestTimeout and context.DeadlineExceeded.
+//
+// Example usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	res, err := client.RequestContext(ctx, req)
+//	if errors.Is(err, xrpl.ErrRequestTimeout) {
+//		// retry or give up
+//	}
+func (c *Client) RequestContext(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+	return c.invoke(ctx, req)
+}
+
+// roundTrip sends req on the websocket and waits for its response. It is the
+// innermost Invoker of the interceptor chain.
+func (c *Client) roundTrip(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, contextError(err)
+	}
+	if c.config.LazyConnect {
+		if err := c.Connect(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	requestId := c.NextID()
 	req["id"] = requestId
 	data, err := json.Marshal(req)
@@ -65,15 +84,127 @@ func (c *Client) Request(req BaseRequest) (BaseResponse, error) {
 	}
 
 	ch := make(chan BaseResponse, 1)
+	command, _ := req["command"].(string)
 
 	c.mutex.Lock()
-	c.requestQueue[requestId] = ch
-	err = c.connection.WriteMessage(websocket.TextMessage, data)
+	if c.state == StateClosing || c.state == StateClosed {
+		c.mutex.Unlock()
+		return nil, ErrClientClosed
+	}
+	if c.connection == nil {
+		c.mutex.Unlock()
+		return nil, ErrNotConnected
+	}
+	c.requestQueue[requestId] = &pendingRequest{
+		ch:         ch,
+		data:       data,
+		idempotent: idempotentCommands[command],
+	}
+	err = c.writeMessage(c.connection, websocket.TextMessage, data)
 	if err != nil {
+		delete(c.requestQueue, requestId)
+		c.mutex.Unlock()
+		c.log.Warn("websocket request failed", "url", c.config.URL, "id", requestId, "command", command, "error", err)
 		return nil, err
 	}
 	c.mutex.Unlock()
+	c.log.Debug("websocket request sent", "url", c.config.URL, "id", requestId, "command", command)
+
+	select {
+	case res, ok := <-ch:
+		if !ok {
+			return nil, ErrConnectionLost
+		}
+		if res["status"] == "error" {
+			return nil, newRPCError(res, req)
+		}
+		return res, nil
+	case <-ctx.Done():
+		c.mutex.Lock()
+		delete(c.requestQueue, requestId)
+		c.mutex.Unlock()
+		return nil, contextError(ctx.Err())
+	}
+}
+
+// contextError maps an expired deadline to ErrRequestTimeout while keeping
+// the original context error in the chain.
+func contextError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", ErrRequestTimeout, err)
+	}
+	return err
+}
+
+// pendingRequest is a request that has been written to the websocket and is
+// waiting for its response. The channel is closed without a value if the
+// connection is lost before the response arrives.
+type pendingRequest struct {
+	ch         chan BaseResponse
+	data       []byte
+	idempotent bool
+}
+
+// Read-only commands that are safe to send again on a new connection if the
+// response was lost to a disconnect.
+var idempotentCommands = map[string]bool{
+	"account_channels":   true,
+	"account_currencies": true,
+	"account_info":       true,
+	"account_lines":      true,
+	"account_nfts":       true,
+	"account_objects":    true,
+	"account_offers":     true,
+	"account_tx":         true,
+	"book_offers":        true,
+	"deposit_authorized": true,
+	"fee":                true,
+	"gateway_balances":   true,
+	"ledger":             true,
+	"ledger_closed":      true,
+	"ledger_current":     true,
+	"ledger_data":        true,
+	"ledger_entry":       true,
+	"nft_buy_offers":     true,
+	"nft_sell_offers":    true,
+	"noripple_check":     true,
+	"ping":               true,
+	"random":             true,
+	"server_info":        true,
+	"server_state":       true,
+	"transaction_entry":  true,
+	"tx":                 true,
+}
 
-	res := <-ch
-	return res, nil
+// failPendingRequests resolves pending requests with ErrConnectionLost. If
+// keepIdempotent is set, idempotent requests are left in the queue so that
+// resendPendingRequests can retry them once a new connection is up.
+func (c *Client) failPendingRequests(keepIdempotent bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for requestId, p := range c.requestQueue {
+		if keepIdempotent && p.idempotent {
+			continue
+		}
+		delete(c.requestQueue, requestId)
+		close(p.ch)
+	}
+}
+
+// resendPendingRequests writes every request still in the queue to the
+// current connection. Requests that cannot be written are failed.
+func (c *Client) resendPendingRequests() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for requestId, p := range c.requestQueue {
+		if c.connection == nil {
+			delete(c.requestQueue, requestId)
+			close(p.ch)
+			continue
+		}
+		if err := c.writeMessage(c.connection, websocket.TextMessage, p.data); err != nil {
+			delete(c.requestQueue, requestId)
+			close(p.ch)
+		}
+	}
 }
@@ -21,9 +21,13 @@ const (
 	StreamTypeConsensus            = "consensus"
 	StreamTypePathFind             = "path_find"
 	StreamTypeServer               = "server"
+	StreamTypeBookChanges          = "book_changes"
 	StreamTypeResponse             = "response"
 )
 
+// Key for the StreamDefault channel, which receives messages of unknown type
+const streamDefault = "default"
+
 // StreamResponseType returns a string denoting 'type' property present in the
 // requested StreamType's response. It returns the empty string if there's no
 // match for the requested StreamType.
@@ -47,6 +51,8 @@ func StreamResponseType(streamType string) string {
 		return "path_find"
 	case StreamTypeServer:
 		return "serverStatus"
+	case StreamTypeBookChanges:
+		return "bookChanges"
 	case StreamTypeResponse:
 		return "response"
 	default:
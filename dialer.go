@@ -0,0 +1,145 @@
+package xrpl
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// newDialer returns a websocket dialer and handshake headers that apply the
+// TLS, authorization and proxy settings of the config.
+func (config *ClientConfig) newDialer() (*websocket.Dialer, http.Header, error) {
+	dialer := *websocket.DefaultDialer
+	header := http.Header{}
+
+	tlsConfig, err := config.newTLSConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	dialer.TLSClientConfig = tlsConfig
+
+	if config.Proxy != "" {
+		proxyURL, err := url.Parse(config.Proxy)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		switch proxyURL.Scheme {
+		case "http", "socks5", "socks5h":
+		default:
+			return nil, nil, fmt.Errorf("unsupported proxy scheme: %q", proxyURL.Scheme)
+		}
+		if config.ProxyAuthorization != "" {
+			username, password, _ := strings.Cut(config.ProxyAuthorization, ":")
+			proxyURL.User = url.UserPassword(username, password)
+		}
+		dialer.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	// Same as xrpl.js: authorization is sent as HTTP basic auth credentials
+	if config.Authorization != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte(config.Authorization))
+		header.Set("Authorization", "Basic "+credentials)
+	}
+
+	return &dialer, header, nil
+}
+
+// newTLSConfig builds the TLS configuration for wss:// connections. It returns
+// nil if no TLS settings are configured, in which case Go's defaults apply.
+func (config *ClientConfig) newTLSConfig() (*tls.Config, error) {
+	if config.TLSConfig == nil &&
+		config.Certificate == "" &&
+		config.Key == "" &&
+		len(config.TrustedCertificates) == 0 {
+		return nil, nil
+	}
+
+	var tlsConfig *tls.Config
+	if config.TLSConfig != nil {
+		tlsConfig = config.TLSConfig.Clone()
+	} else {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	if config.Certificate != "" || config.Key != "" {
+		if config.Certificate == "" || config.Key == "" {
+			return nil, errors.New("client certificate and key must be set together")
+		}
+		certPEM, err := readPEM(config.Certificate)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read client certificate: %w", err)
+		}
+		keyPEM, err := readPEM(config.Key)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read client key: %w", err)
+		}
+		keyPEM, err = decryptKey(keyPEM, config.Passphrase)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+	}
+
+	if len(config.TrustedCertificates) > 0 {
+		pool := x509.NewCertPool()
+		for _, certificate := range config.TrustedCertificates {
+			certPEM, err := readPEM(certificate)
+			if err != nil {
+				return nil, fmt.Errorf("cannot read trusted certificate: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(certPEM) {
+				return nil, errors.New("no valid certificate found in trusted certificate")
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// readPEM returns value if it holds PEM encoded data, or else the contents of
+// the file value refers to.
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
+
+// decryptKey decrypts a passphrase protected PEM private key. Unencrypted keys
+// are returned as they are.
+func decryptKey(keyPEM []byte, passphrase string) ([]byte, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("client key is not PEM encoded")
+	}
+	if block.Type == "ENCRYPTED PRIVATE KEY" {
+		return nil, errors.New("encrypted PKCS#8 client keys are not supported, use a PEM encrypted key instead")
+	}
+	// Legacy PEM encryption is deprecated in crypto/x509 but is still what
+	// "openssl genrsa -aes256" and rippled's tooling produce.
+	if !x509.IsEncryptedPEMBlock(block) {
+		return keyPEM, nil
+	}
+	if passphrase == "" {
+		return nil, errors.New("client key is encrypted but no passphrase is set")
+	}
+	der, err := x509.DecryptPEMBlock(block, []byte(passphrase))
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt client key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: der}), nil
+}
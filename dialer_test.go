@@ -0,0 +1,211 @@
+package xrpl_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/xrpscan/xrpl-go"
+)
+
+// websocketServer accepts websocket connections and passes each handshake
+// request to onConnect. The server is not started yet.
+func websocketServer(onConnect func(r *http.Request)) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		onConnect(r)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+}
+
+func wsURL(srv *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func TestDialAuthorization(t *testing.T) {
+	headers := make(chan http.Header, 1)
+	srv := websocketServer(func(r *http.Request) { headers <- r.Header })
+	srv.Start()
+	defer srv.Close()
+
+	client, err := xrpl.Dial(context.Background(), wsURL(srv), func(config *xrpl.ClientConfig) {
+		config.Authorization = "user:secret"
+	})
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer client.Close()
+
+	header := <-headers
+	if got, want := header.Get("Authorization"), "Basic dXNlcjpzZWNyZXQ="; got != want {
+		t.Errorf("Authorization = %q, want %q", got, want)
+	}
+}
+
+func TestDialInvalidTLSAndProxyConfig(t *testing.T) {
+	certPEM, keyPEM, _ := newCertificate(t, "client", nil)
+
+	configs := map[string]struct {
+		option xrpl.Option
+		err    string
+	}{
+		"certificate without key": {
+			xrpl.WithClientCertificate(string(certPEM), "", ""),
+			"certificate and key must be set together",
+		},
+		"key without certificate": {
+			xrpl.WithClientCertificate("", string(keyPEM), ""),
+			"certificate and key must be set together",
+		},
+		"invalid trusted certificate": {
+			xrpl.WithTrustedCertificates("-----BEGIN CERTIFICATE-----\nnot a certificate\n-----END CERTIFICATE-----\n"),
+			"no valid certificate",
+		},
+		"unsupported proxy scheme": {
+			func(config *xrpl.ClientConfig) { config.Proxy = "ftp://127.0.0.1:21" },
+			"unsupported proxy scheme",
+		},
+	}
+	for name, c := range configs {
+		client, err := xrpl.Dial(context.Background(), "ws://127.0.0.1:1", c.option, xrpl.WithLazyConnect())
+		if err == nil {
+			client.Close()
+			t.Errorf("%s: Dial succeeded", name)
+			continue
+		}
+		if !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s: err = %v, want %q", name, err, c.err)
+		}
+	}
+}
+
+// A client certificate with an encrypted key is presented to a server that
+// requires it, and the server is verified against a custom root.
+func TestDialMutualTLS(t *testing.T) {
+	caPEM, _, ca := newCertificate(t, "ca", nil)
+	serverCertPEM, serverKeyPEM, _ := newCertificate(t, "server", ca)
+	clientCertPEM, clientKeyPEM, _ := newCertificate(t, "client", ca)
+
+	block, _ := pem.Decode(clientKeyPEM)
+	// Legacy PEM encryption, as produced by "openssl genrsa -aes256"
+	encrypted, err := x509.EncryptPEMBlock(rand.Reader, block.Type, block.Bytes, []byte("secret"), x509.PEMCipherAES256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptedKeyPEM := string(pem.EncodeToMemory(encrypted))
+
+	serverCert, err := tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(caPEM)
+
+	peers := make(chan string, 1)
+	srv := websocketServer(func(r *http.Request) {
+		if len(r.TLS.PeerCertificates) > 0 {
+			peers <- r.TLS.PeerCertificates[0].Subject.CommonName
+		}
+	})
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    roots,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+	url := "wss" + strings.TrimPrefix(srv.URL, "https")
+
+	// Without the passphrase the key cannot be used
+	if _, err := xrpl.Dial(context.Background(), url,
+		xrpl.WithTrustedCertificates(string(caPEM)),
+		xrpl.WithClientCertificate(string(clientCertPEM), encryptedKeyPEM, ""),
+	); err == nil || !strings.Contains(err.Error(), "no passphrase") {
+		t.Errorf("Dial without passphrase: err = %v", err)
+	}
+
+	// Without the custom root the server is not trusted
+	if client, err := xrpl.Dial(context.Background(), url,
+		xrpl.WithClientCertificate(string(clientCertPEM), encryptedKeyPEM, "secret"),
+	); err == nil {
+		client.Close()
+		t.Error("Dial succeeded without trusting the server's CA")
+	}
+
+	client, err := xrpl.Dial(context.Background(), url,
+		xrpl.WithTrustedCertificates(string(caPEM)),
+		xrpl.WithClientCertificate(string(clientCertPEM), encryptedKeyPEM, "secret"),
+	)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer client.Close()
+	if peer := <-peers; peer != "client" {
+		t.Errorf("server saw client certificate %q", peer)
+	}
+}
+
+// newCertificate creates a certificate for 127.0.0.1, signed by parent, or a
+// self-signed CA certificate if parent is nil.
+func newCertificate(t *testing.T, name string, parent *tls.Certificate) (certPEM, keyPEM []byte, cert *tls.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, interface{}(key)
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer = parent.Leaf
+		signerKey = parent.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPEM, keyPEM, &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
@@ -0,0 +1,73 @@
+package xrpl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// TypedRequest is implemented by the request structs of the methods package,
+// e.g. methods.AccountInfoRequest, and by BaseRequest. Method returns the
+// rippled command.
+type TypedRequest interface {
+	Method() string
+}
+
+// Method returns the request's command.
+func (r BaseRequest) Method() string {
+	command, _ := r["command"].(string)
+	return command
+}
+
+// Do sends a typed request over any Transport and decodes the response into
+// Res, typically the request's matching response struct. The command is taken
+// from req.Method() and the request id is assigned by the transport. Error
+// responses from rippled are returned as *RPCError, and responses that do not
+// decode into Res as an error wrapping ErrMalformedMessage.
+//
+// Example usage:
+//
+//	res, err := xrpl.Do[methods.AccountInfoRequest, methods.AccountInfoResponse](ctx, client, methods.AccountInfoRequest{
+//		Account:     "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn",
+//		LedgerIndex: "validated",
+//	})
+//	fmt.Println(res.Result.AccountData.Balance)
+func Do[Req TypedRequest, Res any](ctx context.Context, t Transport, req Req) (Res, error) {
+	var res Res
+	request, err := toBaseRequest(req)
+	if err != nil {
+		return res, err
+	}
+
+	response, err := t.RequestContext(ctx, request)
+	if err != nil {
+		return res, err
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return res, err
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return res, fmt.Errorf("%w: cannot decode %s response: %w", ErrMalformedMessage, req.Method(), err)
+	}
+	return res, nil
+}
+
+// toBaseRequest converts a typed request to a BaseRequest, keeping numbers
+// exact.
+func toBaseRequest(req TypedRequest) (BaseRequest, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	request := BaseRequest{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&request); err != nil {
+		return nil, err
+	}
+	request["command"] = req.Method()
+	return request, nil
+}
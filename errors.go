@@ -0,0 +1,91 @@
+package xrpl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/xrpscan/xrpl-go/models"
+)
+
+// ErrRequestTimeout is returned by RequestContext when the request's context
+// deadline expires before a response is received. The returned error also
+// wraps context.DeadlineExceeded.
+var ErrRequestTimeout = errors.New("request timed out")
+
+// ErrConnectionLost is returned for requests that were still waiting for a
+// response when the websocket connection dropped or was closed.
+var ErrConnectionLost = errors.New("connection lost before response was received")
+
+// ErrNotConnected is returned when a request is made while the client has no
+// open websocket connection, e.g. while it is reconnecting.
+var ErrNotConnected = errors.New("not connected")
+
+// ErrClientClosed is returned by operations on a client that has been closed.
+var ErrClientClosed = errors.New("client is closed")
+
+// ErrHeartbeatTimeout is reported on the Errors channel when a connection
+// is dropped because too many heartbeats in a row went unanswered.
+var ErrHeartbeatTimeout = errors.New("heartbeat timed out")
+
+// ErrMalformedMessage is reported on the Errors channel for websocket messages
+// that cannot be decoded, and returned by Do for responses that do not match
+// the expected response type.
+var ErrMalformedMessage = errors.New("malformed message")
+
+// RPCError is returned when rippled answers a request with status "error".
+// Use errors.Is with one of the Err* values below to check for a specific
+// error, or errors.As to inspect the full error.
+//
+// Reference: https://xrpl.org/error-formatting.html
+type RPCError struct {
+	Name    string      // Short error name, e.g. "actNotFound"
+	Code    int         // Numeric error code
+	Message string      // Human readable error message
+	Request BaseRequest // Request that caused the error
+}
+
+// Common rippled errors, usable as errors.Is targets.
+var (
+	ErrActNotFound = &RPCError{Name: "actNotFound"}
+	ErrLgrNotFound = &RPCError{Name: "lgrNotFound"}
+	ErrTxnNotFound = &RPCError{Name: "txnNotFound"}
+	ErrTooBusy     = &RPCError{Name: "tooBusy"}
+	ErrNoNetwork   = &RPCError{Name: "noNetwork"}
+	ErrSlowDown    = &RPCError{Name: "slowDown"}
+)
+
+func (e *RPCError) Error() string {
+	if e.Message == "" {
+		return e.Name
+	}
+	return fmt.Sprintf("%s: %s", e.Name, e.Message)
+}
+
+// Is reports whether target is an *RPCError with the same error name.
+func (e *RPCError) Is(target error) bool {
+	t, ok := target.(*RPCError)
+	return ok && t.Name == e.Name
+}
+
+// IsRetryable reports whether err is a transient rippled error after which the
+// same request may succeed if sent again later.
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrTooBusy) ||
+		errors.Is(err, ErrNoNetwork) ||
+		errors.Is(err, ErrSlowDown)
+}
+
+// newRPCError builds an *RPCError from an error response to req.
+func newRPCError(res BaseResponse, req BaseRequest) *RPCError {
+	var m models.ErrorResponse
+	if data, err := json.Marshal(res); err == nil {
+		json.Unmarshal(data, &m)
+	}
+	return &RPCError{
+		Name:    m.Error,
+		Code:    m.ErrorCode,
+		Message: m.ErrorMessage,
+		Request: req,
+	}
+}
@@ -0,0 +1,299 @@
+package xrpl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/xrpscan/xrpl-go/models"
+)
+
+// ClosedLedger is a ledger emitted by LedgerFollower.
+type ClosedLedger struct {
+	models.LedgerStream
+
+	// Set if the ledger was missing from the ledger stream and was fetched
+	// with the ledger command instead. Fee and reserve fields are not known
+	// for backfilled ledgers.
+	Backfilled bool
+
+	// Ledger header and expanded transactions as returned by the ledger
+	// command. Only set for backfilled ledgers, unless
+	// LedgerFollowerConfig.FetchTransactions is set.
+	Ledger map[string]interface{}
+}
+
+type LedgerFollowerConfig struct {
+	// First ledger to emit. Ledgers between StartIndex and the first ledger
+	// seen on the stream are backfilled. Default: start with the first
+	// ledger seen on the stream.
+	StartIndex uint64
+
+	// Fetch every ledger with its transactions, not only backfilled ones
+	FetchTransactions bool
+
+	// Timeout of a single ledger request. Default: 20s
+	RequestTimeout time.Duration
+
+	// Attempts to fetch a ledger before Run gives up. Default: 5
+	MaxAttempts int
+
+	// Capacity of the Ledgers channel. Default: 128
+	QueueCapacity int
+}
+
+// LedgerFollower turns the ledger stream of a Client into a strictly ordered,
+// gap-free sequence of closed ledgers on the Ledgers channel. Ledgers missed
+// by the stream, e.g. while the client was reconnecting, are fetched with the
+// ledger command.
+//
+// The follower consumes the client's StreamLedger channel, so nothing else
+// may read it, and no OnLedger handler may be registered.
+//
+// Example usage:
+//
+//	follower := xrpl.NewLedgerFollower(client, xrpl.LedgerFollowerConfig{})
+//	go follower.Run(ctx)
+//	for ledger := range follower.Ledgers {
+//		fmt.Println(ledger.LedgerIndex, ledger.Backfilled)
+//	}
+type LedgerFollower struct {
+	client    *Client
+	config    LedgerFollowerConfig
+	lastIndex uint64
+	started   bool
+	Ledgers   chan ClosedLedger
+}
+
+func NewLedgerFollower(client *Client, config LedgerFollowerConfig) *LedgerFollower {
+	if config.RequestTimeout == 0 {
+		config.RequestTimeout = 20 * time.Second
+	}
+	if config.MaxAttempts == 0 {
+		config.MaxAttempts = 5
+	}
+	if config.QueueCapacity == 0 {
+		config.QueueCapacity = 128
+	}
+	return &LedgerFollower{
+		client:  client,
+		config:  config,
+		Ledgers: make(chan ClosedLedger, config.QueueCapacity),
+	}
+}
+
+// LastIndex returns the index of the last ledger emitted. It is only safe to
+// call after Run has returned.
+func (f *LedgerFollower) LastIndex() uint64 {
+	return f.lastIndex
+}
+
+// Run subscribes to the ledger stream and emits ledgers until ctx is done or
+// a missing ledger cannot be fetched. The Ledgers channel is closed when Run
+// returns.
+//
+// The stream is read continuously, also while missing ledgers are fetched.
+// Otherwise a full StreamLedger channel would block the client's read loop,
+// and with it the responses to the follower's own ledger requests.
+func (f *LedgerFollower) Run(ctx context.Context) error {
+	defer close(f.Ledgers)
+
+	if f.config.StartIndex > 0 {
+		f.lastIndex = f.config.StartIndex - 1
+		f.started = true
+	}
+	if _, err := f.client.SubscribeContext(ctx, []string{StreamTypeLedger}); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	buffer := &ledgerBuffer{notify: make(chan struct{}, 1)}
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		f.drain(ctx, buffer)
+	}()
+	defer func() {
+		cancel()
+		<-drained
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-buffer.notify:
+			for _, ledger := range buffer.take() {
+				if err := f.follow(ctx, ledger); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// ledgerBuffer holds ledger stream messages received while the follower is
+// busy. It is unbounded, but only grows by one small message per ledger.
+type ledgerBuffer struct {
+	mutex   sync.Mutex
+	ledgers []models.LedgerStream
+	notify  chan struct{}
+}
+
+func (b *ledgerBuffer) push(ledger models.LedgerStream) {
+	b.mutex.Lock()
+	b.ledgers = append(b.ledgers, ledger)
+	b.mutex.Unlock()
+	select {
+	case b.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (b *ledgerBuffer) take() []models.LedgerStream {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	ledgers := b.ledgers
+	b.ledgers = nil
+	return ledgers
+}
+
+// drain decodes ledger stream messages into buffer until ctx is done.
+func (f *LedgerFollower) drain(ctx context.Context, buffer *ledgerBuffer) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message := <-f.client.StreamLedger:
+			var ledger models.LedgerStream
+			if err := json.Unmarshal(message, &ledger); err != nil {
+				f.client.reportError("cannot decode ledger stream message", fmt.Errorf("%w: %w", ErrMalformedMessage, err))
+				continue
+			}
+			buffer.push(ledger)
+		}
+	}
+}
+
+// follow emits any ledgers missing before ledger, followed by ledger itself.
+func (f *LedgerFollower) follow(ctx context.Context, ledger models.LedgerStream) error {
+	if f.started && ledger.LedgerIndex <= f.lastIndex {
+		// Already emitted, e.g. repeated after a reconnect
+		return nil
+	}
+
+	if f.started {
+		for index := f.lastIndex + 1; index < ledger.LedgerIndex; index++ {
+			closed, err := f.fetch(ctx, index)
+			if err != nil {
+				return err
+			}
+			if err := f.emit(ctx, closed); err != nil {
+				return err
+			}
+		}
+	}
+
+	closed := ClosedLedger{LedgerStream: ledger}
+	if f.config.FetchTransactions {
+		fetched, err := f.fetch(ctx, ledger.LedgerIndex)
+		if err != nil {
+			return err
+		}
+		closed.Ledger = fetched.Ledger
+	}
+	return f.emit(ctx, closed)
+}
+
+func (f *LedgerFollower) emit(ctx context.Context, ledger ClosedLedger) error {
+	select {
+	case f.Ledgers <- ledger:
+		f.lastIndex = ledger.LedgerIndex
+		f.started = true
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// fetch requests a validated ledger with expanded transactions, retrying
+// transient failures.
+func (f *LedgerFollower) fetch(ctx context.Context, index uint64) (ClosedLedger, error) {
+	var err error
+	for attempt := 1; attempt <= f.config.MaxAttempts; attempt++ {
+		var closed ClosedLedger
+		closed, err = f.fetchOnce(ctx, index)
+		if err == nil {
+			return closed, nil
+		}
+		if ctx.Err() != nil {
+			return ClosedLedger{}, ctx.Err()
+		}
+		var rpcErr *RPCError
+		if errors.As(err, &rpcErr) && !IsRetryable(err) {
+			break
+		}
+		f.client.log.Warn("ledger backfill failed", "url", f.client.config.URL, "ledger_index", index, "attempt", attempt, "error", err)
+
+		select {
+		case <-time.After(time.Duration(attempt) * time.Second):
+		case <-ctx.Done():
+			return ClosedLedger{}, ctx.Err()
+		}
+	}
+	return ClosedLedger{}, fmt.Errorf("cannot fetch ledger %d: %w", index, err)
+}
+
+func (f *LedgerFollower) fetchOnce(ctx context.Context, index uint64) (ClosedLedger, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.config.RequestTimeout)
+	defer cancel()
+	res, err := f.client.RequestContext(ctx, BaseRequest{
+		"command":      "ledger",
+		"ledger_index": index,
+		"transactions": true,
+		"expand":       true,
+	})
+	if err != nil {
+		return ClosedLedger{}, err
+	}
+
+	result, _ := res["result"].(map[string]interface{})
+	ledger, ok := result["ledger"].(map[string]interface{})
+	if !ok {
+		return ClosedLedger{}, errors.New("ledger response has no ledger")
+	}
+	transactions, _ := ledger["transactions"].([]interface{})
+	closed := ClosedLedger{
+		LedgerStream: models.LedgerStream{
+			Type:        StreamResponseType(StreamTypeLedger),
+			LedgerHash:  stringValue(ledger["ledger_hash"]),
+			LedgerIndex: index,
+			LedgerTime:  uintValue(ledger["close_time"]),
+			TxnCount:    uint64(len(transactions)),
+		},
+		Backfilled: true,
+		Ledger:     ledger,
+	}
+	return closed, nil
+}
+
+func stringValue(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+// uintValue converts a JSON number, or a number encoded as string, to uint64.
+func uintValue(v interface{}) uint64 {
+	switch n := v.(type) {
+	case float64:
+		return uint64(n)
+	case string:
+		u, _ := strconv.ParseUint(n, 10, 64)
+		return u
+	}
+	return 0
+}
@@ -0,0 +1,99 @@
+package xrpl_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/xrpscan/xrpl-go"
+	"github.com/xrpscan/xrpl-go/models"
+	"github.com/xrpscan/xrpl-go/xrpltest"
+)
+
+// Stream messages arriving during a long backfill must not block the
+// client's read loop, or the follower's own ledger requests time out.
+func TestLedgerFollowerBackfillWhileStreaming(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+	srv.Handle("ledger", func(req xrpl.BaseRequest) (interface{}, error) {
+		return map[string]interface{}{
+			"ledger": map[string]interface{}{
+				"ledger_hash":  fmt.Sprint("HASH", req["ledger_index"]),
+				"close_time":   750000000,
+				"transactions": []interface{}{},
+			},
+		}, nil
+	})
+	srv.SetCommandLatency("ledger", 20*time.Millisecond)
+
+	client, err := xrpl.Dial(context.Background(), srv.URL, xrpl.WithQueueCapacity(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	follower := xrpl.NewLedgerFollower(client, xrpl.LedgerFollowerConfig{
+		StartIndex:     1,
+		RequestTimeout: time.Second,
+		MaxAttempts:    1,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := make(chan error, 1)
+	go func() { errs <- follower.Run(ctx) }()
+
+	waitFor(t, "subscribe", func() bool {
+		for _, req := range srv.Requests() {
+			if req["command"] == "subscribe" {
+				return true
+			}
+		}
+		return false
+	})
+
+	// Ledgers 1-39 are backfilled while 40-60 arrive on the stream
+	go func() {
+		for index := uint64(40); index <= 60; index++ {
+			srv.EmitLedgerClosed(models.LedgerStream{LedgerIndex: index})
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	for want := uint64(1); want <= 60; want++ {
+		select {
+		case ledger, ok := <-follower.Ledgers:
+			if !ok {
+				t.Fatalf("Ledgers closed before ledger %d: %v", want, <-errs)
+			}
+			if ledger.LedgerIndex != want {
+				t.Fatalf("got ledger %d, want %d", ledger.LedgerIndex, want)
+			}
+			if ledger.Backfilled != (want < 40) {
+				t.Errorf("ledger %d: Backfilled = %t", want, ledger.Backfilled)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for ledger %d", want)
+		}
+	}
+
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run returned %v, want context.Canceled", err)
+	}
+	if _, ok := <-follower.Ledgers; ok {
+		t.Error("Ledgers not closed after Run returned")
+	}
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
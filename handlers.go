@@ -3,34 +3,30 @@ package xrpl
 import (
 	"encoding/json"
 	"fmt"
-	"log"
-	"time"
 
 	"github.com/gorilla/websocket"
 )
 
-func (c *Client) handlePong(message string) error {
-	// log.Println("PONG:", message)
-	c.connection.SetReadDeadline(time.Now().Add(c.config.ReadTimeout * time.Second))
-	c.connection.SetWriteDeadline(time.Now().Add(c.config.WriteTimeout * time.Second))
-	return nil
-}
-
-func (c *Client) handleResponse() error {
+func (c *Client) handleResponse(conn *websocket.Conn) error {
 	for {
-		if c.closed {
-			break
-		}
-		messageType, message, err := c.connection.ReadMessage()
+		messageType, message, err := conn.ReadMessage()
 		if err != nil {
-			log.Println("WS read error:", err)
+			c.mutex.Lock()
+			stale := c.connection != conn
+			c.mutex.Unlock()
+			if stale {
+				// Connection was closed or replaced on purpose. Whoever did
+				// that is responsible for pending requests.
+				break
+			}
+			c.reportError("websocket read failed", err)
 			c.Reconnect()
 			break
 		}
 
 		switch messageType {
 		case websocket.CloseMessage:
-			log.Println("WS websocket.CloseMessage received")
+			c.log.Info("websocket close message received", "url", c.config.URL)
 			return nil
 		case websocket.TextMessage:
 			c.resolveStream(message)
@@ -44,46 +40,64 @@ func (c *Client) handleResponse() error {
 func (c *Client) resolveStream(message []byte) {
 	var m BaseResponse
 	if err := json.Unmarshal(message, &m); err != nil {
-		log.Println("json.Unmarshal error: ", err)
+		c.reportError("cannot decode websocket message", fmt.Errorf("%w: %w", ErrMalformedMessage, err))
+		return
+	}
+
+	responseType, _ := m["type"].(string)
+	c.log.Debug("websocket message received", "url", c.config.URL, "type", responseType)
+	if responseType == StreamResponseType(StreamTypeResponse) {
+		requestId := fmt.Sprintf("%v", m["id"])
+		c.mutex.Lock()
+		p, ok := c.requestQueue[requestId]
+		if ok {
+			p.ch <- m
+			delete(c.requestQueue, requestId)
+			close(p.ch)
+		}
+		c.mutex.Unlock()
+		return
+	}
+	c.deliver(responseType, message)
+}
+
+// deliverStream hands a stream message to its typed handlers or, if there are
+// none, to its stream channel. It is the innermost StreamHandler of the
+// stream interceptor chain.
+func (c *Client) deliverStream(responseType string, message []byte) {
+	if c.dispatch(responseType, message) {
+		return
 	}
 
-	switch m["type"] {
+	switch responseType {
 	case StreamResponseType(StreamTypeLedger):
-		c.StreamLedger <- message
+		c.streams[StreamTypeLedger].push(message)
 
 	case StreamResponseType(StreamTypeTransaction):
-		c.StreamTransaction <- message
+		c.streams[StreamTypeTransaction].push(message)
 
 	case StreamResponseType(StreamTypeValidations):
-		c.StreamValidation <- message
+		c.streams[StreamTypeValidations].push(message)
 
 	case StreamResponseType(StreamTypeManifests):
-		c.StreamManifest <- message
+		c.streams[StreamTypeManifests].push(message)
 
 	case StreamResponseType(StreamTypePeerStatus):
-		c.StreamPeerStatus <- message
+		c.streams[StreamTypePeerStatus].push(message)
 
 	case StreamResponseType(StreamTypeConsensus):
-		c.StreamConsensus <- message
+		c.streams[StreamTypeConsensus].push(message)
 
 	case StreamResponseType(StreamTypePathFind):
-		c.StreamPathFind <- message
+		c.streams[StreamTypePathFind].push(message)
 
 	case StreamResponseType(StreamTypeServer):
-		c.StreamServer <- message
+		c.streams[StreamTypeServer].push(message)
 
-	case StreamResponseType(StreamTypeResponse):
-		requestId := fmt.Sprintf("%v", m["id"])
-		c.mutex.Lock()
-		ch, ok := c.requestQueue[requestId]
-		if ok {
-			ch <- m
-			delete(c.requestQueue, requestId)
-			close(ch)
-		}
-		c.mutex.Unlock()
+	case StreamResponseType(StreamTypeBookChanges):
+		c.streams[StreamTypeBookChanges].push(message)
 
 	default:
-		c.StreamDefault <- message
+		c.streams[streamDefault].push(message)
 	}
 }
@@ -1,23 +1,204 @@
 package xrpl
 
 import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
 	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// HeartbeatMode selects how a Client checks that its connection is alive.
+type HeartbeatMode string
+
+const (
+	// Send websocket ping control frames. The round trip ends when the
+	// server's websocket layer answers with a pong.
+	HeartbeatPing HeartbeatMode = "ping"
+
+	// Send rippled's ping command. The round trip includes rippled's request
+	// handling, so a stalled server is detected even if its websocket layer
+	// still answers pings.
+	HeartbeatCommand HeartbeatMode = "command"
 )
 
-// Heartbeat runner to send Pings periodically. If a Pong is received, it is
-// handled by handlePong handler which further extends websocket connection's
-// read and write deadline into the future.
-func (c *Client) heartbeat() {
-	// log.Println("INF: Heartbeat started")
-	ticker := time.NewTicker(c.config.HeartbeatInterval * time.Second)
+func (m HeartbeatMode) valid() bool {
+	switch m {
+	case HeartbeatPing, HeartbeatCommand:
+		return true
+	}
+	return false
+}
+
+// Number of recent round trips used to compute RTTStats.P99
+const rttWindow = 128
+
+// Weight of a new sample in RTTStats.Average
+const rttAlpha = 0.125
+
+// RTTStats are heartbeat round trip statistics of the current connection.
+// They are reset whenever a new connection is established.
+type RTTStats struct {
+	Last    time.Duration // Most recent round trip
+	Average time.Duration // Exponentially weighted moving average
+	P99     time.Duration // 99th percentile of the last 128 round trips
+	Samples uint64        // Round trips measured on this connection
+	Missed  int           // Consecutive heartbeats without a response
+}
+
+type rttStats struct {
+	mutex   sync.Mutex
+	stats   RTTStats
+	window  []time.Duration
+	pending string
+	sentAt  time.Time
+}
+
+func (r *rttStats) reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.stats = RTTStats{}
+	r.window = r.window[:0]
+	r.pending = ""
+}
+
+func (r *rttStats) add(rtt time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.stats.Samples == 0 {
+		r.stats.Average = rtt
+	} else {
+		r.stats.Average += time.Duration(rttAlpha * float64(rtt-r.stats.Average))
+	}
+	r.stats.Last = rtt
+	r.stats.Samples++
+	r.stats.Missed = 0
+
+	if len(r.window) < rttWindow {
+		r.window = append(r.window, rtt)
+	} else {
+		r.window[(r.stats.Samples-1)%rttWindow] = rtt
+	}
+	sorted := make([]time.Duration, len(r.window))
+	copy(sorted, r.window)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	r.stats.P99 = sorted[int(math.Ceil(0.99*float64(len(sorted))))-1]
+}
+
+// miss counts a heartbeat without response and returns the number of
+// consecutive misses.
+func (r *rttStats) miss() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.stats.Missed++
+	return r.stats.Missed
+}
+
+// RTT returns heartbeat round trip statistics of the current connection.
+func (c *Client) RTT() RTTStats {
+	c.rtt.mutex.Lock()
+	defer c.rtt.mutex.Unlock()
+	return c.rtt.stats
+}
+
+func (c *Client) handlePong(conn *websocket.Conn, message string) error {
+	conn.SetReadDeadline(time.Now().Add(c.config.ReadTimeout))
+
+	c.rtt.mutex.Lock()
+	matched := c.rtt.pending != "" && message == c.rtt.pending
+	sentAt := c.rtt.sentAt
+	if matched {
+		c.rtt.pending = ""
+	}
+	c.rtt.mutex.Unlock()
+	if matched {
+		c.rtt.add(time.Since(sentAt))
+	}
+	return nil
+}
+
+// Heartbeat runner to check the connection periodically. Pings are sent as
+// configured by ClientConfig.HeartbeatMode. A pong extends the websocket
+// connection's read and write deadline into the future and records the round
+// trip. After HeartbeatMaxMissed heartbeats in a row without a response, the
+// connection is considered dead and the client reconnects. Monitoring goes on
+// until the connection has been replaced, so a reconnect that did not happen
+// is retried on the next miss.
+func (c *Client) heartbeat(conn *websocket.Conn, done chan struct{}) {
+	interval := c.config.HeartbeatInterval
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 	for {
 		select {
-		case <-c.heartbeatDone:
-			ticker.Stop()
-			// log.Println("ERR: Heartbeat stopped")
+		case <-done:
 			return
-		case t := <-ticker.C:
-			c.Ping([]byte(t.String()))
+		case <-ticker.C:
+			var alive bool
+			if c.config.HeartbeatMode == HeartbeatCommand {
+				alive = c.pingCommand(done, interval)
+			} else {
+				alive = c.pingFrame()
+			}
+			if alive {
+				continue
+			}
+
+			missed := c.rtt.miss()
+			c.log.Debug("heartbeat missed", "url", c.config.URL, "missed", missed)
+			if missed < c.config.HeartbeatMaxMissed {
+				continue
+			}
+			c.mutex.Lock()
+			current := c.connection == conn
+			c.mutex.Unlock()
+			if !current {
+				return
+			}
+			c.reportError("websocket connection is dead", ErrHeartbeatTimeout, "missed", missed)
+			c.spawn(func() { c.Reconnect() })
 		}
 	}
 }
+
+// pingFrame sends a websocket ping carrying the current time. It returns
+// false if the previous ping is still unanswered.
+func (c *Client) pingFrame() bool {
+	now := time.Now()
+	payload := strconv.FormatInt(now.UnixNano(), 10)
+
+	c.rtt.mutex.Lock()
+	answered := c.rtt.pending == ""
+	c.rtt.pending = payload
+	c.rtt.sentAt = now
+	c.rtt.mutex.Unlock()
+
+	if err := c.Ping([]byte(payload)); err != nil {
+		c.log.Warn("websocket ping failed", "url", c.config.URL, "error", err)
+		return false
+	}
+	return answered
+}
+
+// pingCommand sends rippled's ping command and waits for the response for at
+// most one heartbeat interval.
+func (c *Client) pingCommand(done chan struct{}, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	start := time.Now()
+	if _, err := c.RequestContext(ctx, BaseRequest{"command": "ping"}); err != nil {
+		return false
+	}
+	c.rtt.add(time.Since(start))
+	return true
+}
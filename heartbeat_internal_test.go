@@ -0,0 +1,45 @@
+package xrpl
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRTTStats(t *testing.T) {
+	var r rttStats
+	for i := 1; i <= 100; i++ {
+		r.add(time.Duration(i) * time.Millisecond)
+	}
+	stats := r.stats
+	if stats.Samples != 100 || stats.Last != 100*time.Millisecond {
+		t.Errorf("Samples = %d, Last = %s", stats.Samples, stats.Last)
+	}
+	if stats.P99 != 99*time.Millisecond {
+		t.Errorf("P99 = %s, want 99ms", stats.P99)
+	}
+	// The moving average trails the rising samples
+	if stats.Average <= 50*time.Millisecond || stats.Average >= 100*time.Millisecond {
+		t.Errorf("Average = %s", stats.Average)
+	}
+
+	// Only the last 128 samples count towards P99
+	for i := 0; i < 128; i++ {
+		r.add(time.Millisecond)
+	}
+	if r.stats.P99 != time.Millisecond {
+		t.Errorf("P99 = %s after the window moved on, want 1ms", r.stats.P99)
+	}
+
+	if missed := r.miss(); missed != 1 {
+		t.Errorf("miss() = %d, want 1", missed)
+	}
+	r.add(time.Millisecond)
+	if r.stats.Missed != 0 {
+		t.Errorf("Missed = %d after a response, want 0", r.stats.Missed)
+	}
+
+	r.reset()
+	if r.stats != (RTTStats{}) || len(r.window) != 0 {
+		t.Errorf("reset left %+v", r.stats)
+	}
+}
@@ -0,0 +1,83 @@
+package xrpl_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/xrpscan/xrpl-go"
+	"github.com/xrpscan/xrpl-go/xrpltest"
+)
+
+func TestHeartbeatRTT(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+	srv.SetCommandLatency("ping", 20*time.Millisecond)
+	client, err := xrpl.Dial(context.Background(), srv.URL,
+		xrpl.WithHeartbeat(50*time.Millisecond, xrpl.HeartbeatCommand),
+		xrpl.WithReadTimeout(time.Second),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	waitFor(t, "heartbeats", func() bool { return client.RTT().Samples >= 3 })
+	rtt := client.RTT()
+	for name, d := range map[string]time.Duration{"Last": rtt.Last, "Average": rtt.Average, "P99": rtt.P99} {
+		if d < 20*time.Millisecond || d >= 50*time.Millisecond {
+			t.Errorf("%s = %s, want between 20ms and 50ms", name, d)
+		}
+	}
+	if rtt.Missed != 0 {
+		t.Errorf("Missed = %d", rtt.Missed)
+	}
+}
+
+// A connection whose heartbeats go unanswered is replaced, and the new
+// connection is monitored in turn.
+func TestHeartbeatReconnectsDeadConnection(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+	var states stateRecorder
+	client, err := xrpl.Dial(context.Background(), srv.URL,
+		xrpl.WithHeartbeat(20*time.Millisecond, xrpl.HeartbeatCommand),
+		xrpl.WithHeartbeatMaxMissed(2),
+		xrpl.WithReadTimeout(time.Second),
+		xrpl.WithStateChange(states.record),
+		fastReconnect,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	reconnects := func() int {
+		var n int
+		for _, state := range states.list() {
+			if state == xrpl.StateReconnecting {
+				n++
+			}
+		}
+		return n
+	}
+
+	for i := 1; i <= 2; i++ {
+		waitFor(t, "heartbeats", func() bool { return client.RTT().Samples > 0 })
+		srv.SetCommandLatency("ping", time.Second)
+		waitFor(t, "reconnect", func() bool { return reconnects() == i })
+		srv.SetCommandLatency("ping", 0)
+		waitFor(t, "open", func() bool { return client.State() == xrpl.StateOpen })
+	}
+
+	var timeout bool
+	for len(client.Errors()) > 0 {
+		if errors.Is(<-client.Errors(), xrpl.ErrHeartbeatTimeout) {
+			timeout = true
+		}
+	}
+	if !timeout {
+		t.Error("ErrHeartbeatTimeout not reported")
+	}
+}
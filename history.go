@@ -0,0 +1,140 @@
+package xrpl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/xrpscan/xrpl-go/methods"
+)
+
+// Checkpoint stores the marker of a long-running walk, such as an account
+// history backfill, so that the walk can resume after a restart.
+type Checkpoint interface {
+	// Load returns the saved marker, or nil to start from the beginning.
+	// done reports whether the walk has already completed.
+	Load() (marker interface{}, done bool, err error)
+
+	// Save stores the marker to resume from. Once the walk has completed,
+	// done is set and marker is nil.
+	Save(marker interface{}, done bool) error
+}
+
+// FileCheckpoint is a Checkpoint stored as JSON in a file. The file is
+// replaced atomically on every Save, so a crash never leaves a partially
+// written checkpoint behind.
+type FileCheckpoint struct {
+	Path string
+}
+
+type fileCheckpoint struct {
+	Marker interface{} `json:"marker"`
+	Done   bool        `json:"done,omitempty"`
+}
+
+func (f FileCheckpoint) Load() (interface{}, bool, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var checkpoint fileCheckpoint
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&checkpoint); err != nil {
+		return nil, false, fmt.Errorf("cannot read checkpoint %s: %w", f.Path, err)
+	}
+	return checkpoint.Marker, checkpoint.Done, nil
+}
+
+func (f FileCheckpoint) Save(marker interface{}, done bool) error {
+	data, err := json.Marshal(fileCheckpoint{Marker: marker, Done: done})
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), filepath.Base(f.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.Path)
+}
+
+// WalkAccountTx fetches the transaction history of req.Account page by page
+// and calls fn with each page. The range and order are taken from req:
+// LedgerIndexMin and LedgerIndexMax bound the ledgers searched (-1 or unset
+// for the oldest and newest available), Forward returns the oldest
+// transactions first, and Binary returns transactions and metadata as hex
+// blobs.
+//
+// If checkpoint is not nil, the walk resumes from the marker it holds, and
+// the marker is saved after fn has returned for each page. A page may thus be
+// passed to fn again after a restart, but none is skipped. When the history
+// has been walked completely, the checkpoint is marked done, and later walks
+// with the same checkpoint return nil right away; delete the checkpoint to
+// walk again. Walking stops at the first error returned by fn or the
+// transport.
+//
+// Example usage:
+//
+//	err := xrpl.WalkAccountTx(ctx, client, methods.AccountTxRequest{
+//		Account: "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn",
+//		Forward: true,
+//	}, xrpl.FileCheckpoint{Path: "account.checkpoint"}, func(page methods.AccountTxResult) error {
+//		return store(page.Transactions)
+//	})
+func WalkAccountTx(ctx context.Context, t Transport, req methods.AccountTxRequest, checkpoint Checkpoint, fn func(page methods.AccountTxResult) error) error {
+	opts := PageOptions{Marker: req.Marker}
+	if checkpoint != nil {
+		marker, done, err := checkpoint.Load()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		if marker != nil {
+			opts.Marker = marker
+		}
+	}
+	req.Marker = nil
+
+	pager := NewPager(ctx, t, req, opts)
+	for pager.Next() {
+		var page methods.AccountTxResult
+		data, err := json.Marshal(pager.Result())
+		if err == nil {
+			err = json.Unmarshal(data, &page)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: cannot decode account_tx page: %w", ErrMalformedMessage, err)
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+		if checkpoint != nil {
+			marker := pager.Marker()
+			if err := checkpoint.Save(marker, marker == nil); err != nil {
+				return fmt.Errorf("cannot save checkpoint: %w", err)
+			}
+		}
+	}
+	return pager.Err()
+}
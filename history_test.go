@@ -0,0 +1,155 @@
+package xrpl_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/xrpscan/xrpl-go"
+	"github.com/xrpscan/xrpl-go/methods"
+	"github.com/xrpscan/xrpl-go/xrpltest"
+)
+
+// accountTxServer serves an account history of three pages with two
+// transactions each. The marker of page n+1 is {"ledger": n+1, "seq": 0}.
+func accountTxServer() *xrpltest.Server {
+	srv := xrpltest.NewServer()
+	srv.Handle("account_tx", func(req xrpl.BaseRequest) (interface{}, error) {
+		page := 0
+		if marker, ok := req["marker"].(map[string]interface{}); ok {
+			ledger, _ := marker["ledger"].(float64)
+			page = int(ledger)
+		}
+		var transactions []interface{}
+		for i := 0; i < 2; i++ {
+			transactions = append(transactions, map[string]interface{}{
+				"hash":         fmt.Sprintf("TX%d-%d", page, i),
+				"ledger_index": 1000 + page,
+				"tx_json":      map[string]interface{}{"TransactionType": "Payment", "DeliverMax": "1"},
+				"meta":         map[string]interface{}{"TransactionResult": "tesSUCCESS"},
+				"validated":    true,
+			})
+		}
+		result := map[string]interface{}{
+			"account":      req["account"],
+			"transactions": transactions,
+		}
+		if page < 2 {
+			result["marker"] = map[string]interface{}{"ledger": page + 1, "seq": 0}
+		}
+		return result, nil
+	})
+	return srv
+}
+
+func accountTxRequests(srv *xrpltest.Server) []xrpl.BaseRequest {
+	var requests []xrpl.BaseRequest
+	for _, req := range srv.Requests() {
+		if req["command"] == "account_tx" {
+			requests = append(requests, req)
+		}
+	}
+	return requests
+}
+
+func TestWalkAccountTxResume(t *testing.T) {
+	srv := accountTxServer()
+	defer srv.Close()
+	client, err := xrpl.Dial(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	checkpoint := xrpl.FileCheckpoint{Path: filepath.Join(t.TempDir(), "account.checkpoint")}
+	req := methods.AccountTxRequest{Account: "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn", Forward: true}
+
+	// Fail on the second page, as if the process crashed
+	failed := errors.New("crash")
+	var hashes []string
+	err = xrpl.WalkAccountTx(context.Background(), client, req, checkpoint, func(page methods.AccountTxResult) error {
+		if len(hashes) == 2 {
+			return failed
+		}
+		for _, tx := range page.Transactions {
+			hashes = append(hashes, tx.Hash)
+		}
+		return nil
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("first walk: err = %v, want %v", err, failed)
+	}
+	marker, done, err := checkpoint.Load()
+	if err != nil || done || marker == nil {
+		t.Fatalf("checkpoint after crash: marker %v, done %t, err %v", marker, done, err)
+	}
+
+	// The second walk resumes with the failed page
+	err = xrpl.WalkAccountTx(context.Background(), client, req, checkpoint, func(page methods.AccountTxResult) error {
+		for _, tx := range page.Transactions {
+			if tx.Transaction.Amount.Value != "1" {
+				t.Errorf("%s: Amount = %+v", tx.Hash, tx.Transaction.Amount)
+			}
+			hashes = append(hashes, tx.Hash)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("second walk: %v", err)
+	}
+	want := []string{"TX0-0", "TX0-1", "TX1-0", "TX1-1", "TX2-0", "TX2-1"}
+	if fmt.Sprint(hashes) != fmt.Sprint(want) {
+		t.Errorf("transactions = %v, want %v", hashes, want)
+	}
+	requests := accountTxRequests(srv)
+	if len(requests) != 4 {
+		t.Fatalf("%d account_tx requests, want 4", len(requests))
+	}
+	if marker, _ := requests[2]["marker"].(map[string]interface{}); marker["ledger"] != float64(1) {
+		t.Errorf("second walk started with marker %v, want page 1", requests[2]["marker"])
+	}
+}
+
+func TestWalkAccountTxCompleted(t *testing.T) {
+	srv := accountTxServer()
+	defer srv.Close()
+	client, err := xrpl.Dial(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	checkpoint := xrpl.FileCheckpoint{Path: filepath.Join(t.TempDir(), "account.checkpoint")}
+	req := methods.AccountTxRequest{Account: "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn"}
+	var pages int
+	walk := func() error {
+		return xrpl.WalkAccountTx(context.Background(), client, req, checkpoint, func(methods.AccountTxResult) error {
+			pages++
+			return nil
+		})
+	}
+
+	if err := walk(); err != nil {
+		t.Fatalf("first walk: %v", err)
+	}
+	if pages != 3 {
+		t.Fatalf("first walk saw %d pages, want 3", pages)
+	}
+	marker, done, err := checkpoint.Load()
+	if err != nil || !done || marker != nil {
+		t.Fatalf("checkpoint after completion: marker %v, done %t, err %v", marker, done, err)
+	}
+
+	// A completed walk is not repeated after a restart
+	if err := walk(); err != nil {
+		t.Fatalf("second walk: %v", err)
+	}
+	if pages != 3 {
+		t.Errorf("completed walk fetched %d more pages", pages-3)
+	}
+	if n := len(accountTxRequests(srv)); n != 3 {
+		t.Errorf("%d account_tx requests, want 3", n)
+	}
+}
@@ -0,0 +1,159 @@
+package xrpl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type HTTPClientConfig struct {
+	URL string
+
+	// Timeout of a single request, unless the request context has an earlier
+	// deadline. Default: 20s
+	Timeout time.Duration
+
+	// HTTP client used to send requests. Default: a new http.Client
+	HTTPClient *http.Client
+}
+
+// HTTPClient sends requests to rippled's JSON-RPC HTTP interface. It only
+// supports request/response methods; use Client for stream subscriptions.
+type HTTPClient struct {
+	config HTTPClientConfig
+	client *http.Client
+	mutex  sync.Mutex
+	nextId int
+}
+
+func (config *HTTPClientConfig) Validate() error {
+	if len(config.URL) == 0 {
+		return errors.New("cannot create a new HTTP client with an empty URL")
+	}
+	if config.Timeout < 0 {
+		return fmt.Errorf("HTTP request timeout out of bounds: %s", config.Timeout)
+	}
+	return nil
+}
+
+// NewHTTPClient returns a JSON-RPC client for the rippled HTTP endpoint at
+// config.URL. Like NewClient, it panics if the configuration is invalid.
+func NewHTTPClient(config HTTPClientConfig) *HTTPClient {
+	if config.Timeout == 0 {
+		config.Timeout = 20 * time.Second
+	}
+
+	if err := config.Validate(); err != nil {
+		panic(err)
+	}
+
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &HTTPClient{
+		config: config,
+		client: client,
+	}
+}
+
+// Returns incremental ID that is attached to responses the same way the
+// websocket Client does
+func (c *HTTPClient) NextID() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.nextId++
+	return strconv.Itoa(c.nextId)
+}
+
+func (c *HTTPClient) Request(req BaseRequest) (BaseResponse, error) {
+	return c.RequestContext(context.Background(), req)
+}
+
+// Send a JSON-RPC request. The request takes the same form as for the
+// websocket Client, and the response is converted to the websocket response
+// format so that callers can handle both alike.
+func (c *HTTPClient) RequestContext(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+	command, _ := req["command"].(string)
+	if command == "" {
+		return nil, errors.New("request has no command")
+	}
+	params := make(map[string]interface{}, len(req))
+	for k, v := range req {
+		if k != "command" && k != "id" {
+			params[k] = v
+		}
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"method": command,
+		"params": []interface{}{params},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpRes, err := c.client.Do(httpReq)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, contextError(ctxErr)
+		}
+		return nil, err
+	}
+	defer httpRes.Body.Close()
+
+	data, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		return nil, err
+	}
+	if httpRes.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP request failed: %s: %s", httpRes.Status, bytes.TrimSpace(data))
+	}
+
+	var rpc struct {
+		Result map[string]interface{} `json:"result"`
+	}
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		return nil, err
+	}
+	if rpc.Result == nil {
+		return nil, errors.New("JSON-RPC response has no result")
+	}
+
+	res := jsonRPCToBaseResponse(rpc.Result)
+	res["id"] = c.NextID()
+	if res["status"] == "error" {
+		return nil, newRPCError(res, req)
+	}
+	return res, nil
+}
+
+// jsonRPCToBaseResponse moves the fields that the websocket API returns at the
+// top level out of a JSON-RPC result.
+func jsonRPCToBaseResponse(result map[string]interface{}) BaseResponse {
+	res := BaseResponse{
+		"type":   StreamTypeResponse,
+		"result": result,
+	}
+	for _, k := range []string{"status", "error", "error_code", "error_message", "request", "warning", "warnings", "forwarded", "api_version"} {
+		if v, ok := result[k]; ok {
+			res[k] = v
+			delete(result, k)
+		}
+	}
+	return res
+}
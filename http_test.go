@@ -0,0 +1,134 @@
+package xrpl_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xrpscan/xrpl-go"
+)
+
+// jsonRPCServer answers every request with status and body, and records the
+// decoded request body.
+func jsonRPCServer(t *testing.T, status int, body string, got *map[string]interface{}) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("%s request with content type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		if got != nil {
+			if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+				t.Errorf("cannot decode request: %v", err)
+			}
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestHTTPClientRequest(t *testing.T) {
+	var body map[string]interface{}
+	srv := jsonRPCServer(t, http.StatusOK, `{"result": {
+		"status": "success",
+		"ledger_current_index": 85000000,
+		"warnings": [{"id": 1004, "message": "This is a reporting server."}]
+	}}`, &body)
+	client := xrpl.NewHTTPClient(xrpl.HTTPClientConfig{URL: srv.URL})
+
+	res, err := client.Request(xrpl.BaseRequest{
+		"command":     "ledger_current",
+		"id":          "7",
+		"api_version": 2,
+	})
+	if err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+
+	// The request is wrapped in method and params
+	if body["method"] != "ledger_current" {
+		t.Errorf("method = %v", body["method"])
+	}
+	params, _ := body["params"].([]interface{})
+	if len(params) != 1 {
+		t.Fatalf("params = %v", body["params"])
+	}
+	param, _ := params[0].(map[string]interface{})
+	if param["api_version"] != float64(2) || param["command"] != nil || param["id"] != nil {
+		t.Errorf("params[0] = %v", param)
+	}
+
+	// The response has the websocket format
+	if res["status"] != "success" || res["type"] != xrpl.StreamTypeResponse || res["id"] == nil {
+		t.Errorf("response = %v", res)
+	}
+	if res["warnings"] == nil {
+		t.Errorf("warnings not moved to the top level: %v", res)
+	}
+	result, _ := res["result"].(map[string]interface{})
+	if result["ledger_current_index"] != float64(85000000) {
+		t.Errorf("result = %v", result)
+	}
+	if _, ok := result["status"]; ok {
+		t.Errorf("status left in result: %v", result)
+	}
+}
+
+func TestHTTPClientRPCError(t *testing.T) {
+	srv := jsonRPCServer(t, http.StatusOK, `{"result": {
+		"status": "error",
+		"error": "actNotFound",
+		"error_code": 19,
+		"error_message": "Account not found.",
+		"request": {"command": "account_info", "account": "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn"}
+	}}`, nil)
+	client := xrpl.NewHTTPClient(xrpl.HTTPClientConfig{URL: srv.URL})
+
+	_, err := client.Request(xrpl.BaseRequest{"command": "account_info", "account": "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn"})
+	if !errors.Is(err, xrpl.ErrActNotFound) {
+		t.Fatalf("err = %v, want actNotFound", err)
+	}
+	var rpcErr *xrpl.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != 19 || rpcErr.Message != "Account not found." {
+		t.Errorf("err = %#v", err)
+	}
+}
+
+func TestHTTPClientStatus(t *testing.T) {
+	srv := jsonRPCServer(t, http.StatusServiceUnavailable, "Server is overloaded\n", nil)
+	client := xrpl.NewHTTPClient(xrpl.HTTPClientConfig{URL: srv.URL})
+
+	_, err := client.Request(xrpl.BaseRequest{"command": "server_info"})
+	if err == nil || !strings.Contains(err.Error(), "503") || !strings.Contains(err.Error(), "Server is overloaded") {
+		t.Errorf("err = %v, want status and body", err)
+	}
+}
+
+func TestHTTPClientTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(300 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	client := xrpl.NewHTTPClient(xrpl.HTTPClientConfig{URL: srv.URL, Timeout: 50 * time.Millisecond})
+
+	_, err := client.Request(xrpl.BaseRequest{"command": "server_info"})
+	if !errors.Is(err, xrpl.ErrRequestTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want ErrRequestTimeout", err)
+	}
+}
+
+func TestHTTPClientNoCommand(t *testing.T) {
+	client := xrpl.NewHTTPClient(xrpl.HTTPClientConfig{URL: "http://127.0.0.1:1"})
+	if _, err := client.Request(xrpl.BaseRequest{"account": "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn"}); err == nil {
+		t.Error("request without command succeeded")
+	}
+}
@@ -0,0 +1,56 @@
+package xrpl
+
+import "context"
+
+// Invoker sends a request and returns its response.
+type Invoker func(ctx context.Context, req BaseRequest) (BaseResponse, error)
+
+// Interceptor wraps every request sent with Client.Request and its variants.
+// It may inspect or rewrite req, call next to pass the request on, and inspect
+// or replace the response. Returning without calling next short-circuits the
+// request, e.g. for caching or rate limiting.
+//
+// Interceptors run in the order they are configured; the first one is the
+// outermost. The request id is assigned after all interceptors have run.
+//
+// Example usage:
+//
+//	apiVersion := func(ctx context.Context, req xrpl.BaseRequest, next xrpl.Invoker) (xrpl.BaseResponse, error) {
+//		req["api_version"] = 2
+//		return next(ctx, req)
+//	}
+//	client, err := xrpl.Dial(ctx, url, xrpl.WithInterceptors(apiVersion))
+type Interceptor func(ctx context.Context, req BaseRequest, next Invoker) (BaseResponse, error)
+
+// StreamHandler receives a stream message along with its type, e.g.
+// "ledgerClosed".
+type StreamHandler func(responseType string, message []byte)
+
+// StreamInterceptor wraps every stream message before it is delivered to
+// typed handlers and stream channels. It may rewrite the message before
+// calling next, or drop it by not calling next. Stream interceptors run on
+// the client's read loop and must not block.
+type StreamInterceptor func(responseType string, message []byte, next StreamHandler)
+
+// chainInterceptors returns an Invoker that runs interceptors around invoker.
+func chainInterceptors(interceptors []Interceptor, invoker Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+			return interceptor(ctx, req, next)
+		}
+	}
+	return invoker
+}
+
+// chainStreamInterceptors returns a StreamHandler that runs interceptors
+// around handler.
+func chainStreamInterceptors(interceptors []StreamInterceptor, handler StreamHandler) StreamHandler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(responseType string, message []byte) {
+			interceptor(responseType, message, next)
+		}
+	}
+	return handler
+}
@@ -0,0 +1,149 @@
+package xrpl_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xrpscan/xrpl-go"
+	"github.com/xrpscan/xrpl-go/models"
+	"github.com/xrpscan/xrpl-go/xrpltest"
+)
+
+func TestInterceptorOrder(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+	srv.On("fee", map[string]interface{}{"current_ledger_size": "12"})
+
+	var mutex sync.Mutex
+	var calls []string
+	record := func(name string) xrpl.Interceptor {
+		return func(ctx context.Context, req xrpl.BaseRequest, next xrpl.Invoker) (xrpl.BaseResponse, error) {
+			mutex.Lock()
+			calls = append(calls, name+" before")
+			mutex.Unlock()
+			req[name] = true
+			res, err := next(ctx, req)
+			mutex.Lock()
+			calls = append(calls, name+" after")
+			mutex.Unlock()
+			if res != nil {
+				res["seen_by"] = name
+			}
+			return res, err
+		}
+	}
+	client, err := xrpl.Dial(context.Background(), srv.URL, xrpl.WithInterceptors(record("a"), record("b")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	res, err := client.Request(xrpl.BaseRequest{"command": "fee"})
+	if err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+
+	mutex.Lock()
+	got := fmt.Sprint(calls)
+	mutex.Unlock()
+	if want := "[a before b before b after a after]"; got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+	// The outermost interceptor has the last word on the response
+	if res["seen_by"] != "a" {
+		t.Errorf("seen_by = %v, want a", res["seen_by"])
+	}
+	// Rewritten requests reach the server
+	requests := srv.Requests()
+	last := requests[len(requests)-1]
+	if last["a"] != true || last["b"] != true {
+		t.Errorf("server received %v", last)
+	}
+}
+
+func TestInterceptorShortCircuit(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+	srv.On("fee", map[string]interface{}{"current_ledger_size": "12"})
+
+	var invoked bool
+	cache := func(ctx context.Context, req xrpl.BaseRequest, next xrpl.Invoker) (xrpl.BaseResponse, error) {
+		if req["command"] == "fee" {
+			return xrpl.BaseResponse{"result": map[string]interface{}{"current_ledger_size": "cached"}}, nil
+		}
+		return next(ctx, req)
+	}
+	inner := func(ctx context.Context, req xrpl.BaseRequest, next xrpl.Invoker) (xrpl.BaseResponse, error) {
+		invoked = true
+		return next(ctx, req)
+	}
+	client, err := xrpl.Dial(context.Background(), srv.URL, xrpl.WithInterceptors(cache, inner))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	before := len(srv.Requests())
+
+	res, err := client.Request(xrpl.BaseRequest{"command": "fee"})
+	if err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+	result, _ := res["result"].(map[string]interface{})
+	if result["current_ledger_size"] != "cached" {
+		t.Errorf("response = %v", res)
+	}
+	if invoked {
+		t.Error("inner interceptor ran for a short-circuited request")
+	}
+	if n := len(srv.Requests()) - before; n != 0 {
+		t.Errorf("server received %d requests", n)
+	}
+}
+
+func TestStreamInterceptors(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+
+	dropValidations := func(responseType string, message []byte, next xrpl.StreamHandler) {
+		if responseType == xrpl.StreamResponseType(xrpl.StreamTypeValidations) {
+			return
+		}
+		next(responseType, message)
+	}
+	shiftLedgers := func(responseType string, message []byte, next xrpl.StreamHandler) {
+		if responseType == xrpl.StreamResponseType(xrpl.StreamTypeLedger) {
+			var ledger models.LedgerStream
+			if err := json.Unmarshal(message, &ledger); err == nil {
+				ledger.LedgerIndex += 100
+				message, _ = json.Marshal(ledger)
+			}
+		}
+		next(responseType, message)
+	}
+	client, err := xrpl.Dial(context.Background(), srv.URL, xrpl.WithStreamInterceptors(dropValidations, shiftLedgers))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	srv.EmitValidation(models.ValidationStream{})
+	srv.EmitLedgerClosed(models.LedgerStream{LedgerIndex: 1})
+	// Messages are handled in order, so both have been delivered or dropped
+	// once the response to a later request is in.
+	if _, err := client.Request(xrpl.BaseRequest{"command": "ping"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readLedgers(t, client, 1); got[0] != 101 {
+		t.Errorf("ledger index = %d, want 101", got[0])
+	}
+	select {
+	case message := <-client.StreamValidation:
+		t.Errorf("dropped validation delivered: %s", message)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
@@ -0,0 +1,158 @@
+package xrpl
+
+import (
+	"context"
+	"sync"
+
+	"github.com/xrpscan/xrpl-go/methods"
+	"github.com/xrpscan/xrpl-go/models"
+)
+
+type LedgerStateConfig struct {
+	// Ledger to dump. Default: models.LedgerValidated
+	Ledger models.LedgerSpecifier
+
+	// Only return entries of this type, e.g. "account" or "offer"
+	Type string
+
+	// Return entries as hex encoded binary in LedgerObject.Data
+	Binary bool
+
+	// Entries per page. Default: the server's default
+	Limit uint32
+
+	// Marker to resume from, as reported in LedgerStateProgress. Ledger must
+	// then be set to the ledger the marker belongs to.
+	Marker interface{}
+
+	// Capacity of the Entries channel. Default: 128
+	QueueCapacity int
+
+	// Called after each page with the progress so far
+	OnProgress func(LedgerStateProgress)
+}
+
+// LedgerStateProgress describes how far a LedgerState walk has come.
+type LedgerStateProgress struct {
+	LedgerIndex uint32      // Ledger being dumped, once the first page is in
+	LedgerHash  string      // Hash of that ledger
+	Pages       int         // Pages fetched so far
+	Entries     uint64      // Entries delivered so far
+	Marker      interface{} // Marker to resume after the last page, nil when done
+}
+
+// LedgerState dumps the complete state of a single ledger by paging through
+// ledger_data. The ledger is pinned to the one returned with the first page,
+// so a shortcut like "validated" does not drift while the walk is in
+// progress. Only one page is held in memory at a time.
+//
+// Entries are passed to a callback with Walk, or sent to the Entries channel
+// with Run.
+//
+// Example usage:
+//
+//	state := xrpl.NewLedgerState(client, xrpl.LedgerStateConfig{Type: "account"})
+//	go state.Run(ctx)
+//	for entry := range state.Entries {
+//		fmt.Println(entry.Index)
+//	}
+type LedgerState struct {
+	t        Transport
+	config   LedgerStateConfig
+	mutex    sync.Mutex
+	progress LedgerStateProgress
+	Entries  chan models.LedgerObject
+}
+
+func NewLedgerState(t Transport, config LedgerStateConfig) *LedgerState {
+	if config.Ledger == "" {
+		config.Ledger = models.LedgerValidated
+	}
+	if config.QueueCapacity == 0 {
+		config.QueueCapacity = 128
+	}
+	return &LedgerState{
+		t:       t,
+		config:  config,
+		Entries: make(chan models.LedgerObject, config.QueueCapacity),
+	}
+}
+
+// Progress returns the progress of the walk so far.
+func (s *LedgerState) Progress() LedgerStateProgress {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.progress
+}
+
+// Walk fetches the ledger state page by page and calls fn with the entries of
+// each page. It stops at the first error returned by fn or the transport, or
+// when ctx is done.
+func (s *LedgerState) Walk(ctx context.Context, fn func(page []models.LedgerObject) error) error {
+	req := methods.LedgerDataRequest{
+		LedgerIndex: s.config.Ledger,
+		Binary:      s.config.Binary,
+		Limit:       s.config.Limit,
+		Marker:      s.config.Marker,
+		Type:        s.config.Type,
+	}
+
+	for {
+		res, err := Do[methods.LedgerDataRequest, methods.LedgerDataResponse](ctx, s.t, req)
+		if err != nil {
+			return err
+		}
+		result := res.Result
+
+		s.mutex.Lock()
+		if s.progress.Pages == 0 {
+			// Pin the ledger, so that later pages come from the same one
+			if seq, ok := result.LedgerIndex.Seq(); ok {
+				s.progress.LedgerIndex = seq
+				req.LedgerIndex = models.LedgerSeq(seq)
+			}
+			if result.LedgerHash != "" {
+				s.progress.LedgerHash = result.LedgerHash
+				req.LedgerHash = result.LedgerHash
+				req.LedgerIndex = ""
+			}
+		}
+		s.mutex.Unlock()
+
+		if err := fn(result.State); err != nil {
+			return err
+		}
+
+		s.mutex.Lock()
+		s.progress.Pages++
+		s.progress.Entries += uint64(len(result.State))
+		s.progress.Marker = result.Marker
+		progress := s.progress
+		s.mutex.Unlock()
+		if s.config.OnProgress != nil {
+			s.config.OnProgress(progress)
+		}
+
+		if result.Marker == nil {
+			return nil
+		}
+		req.Marker = result.Marker
+	}
+}
+
+// Run walks the ledger state and sends every entry to the Entries channel,
+// which is closed when Run returns. Memory use is bounded by one page plus
+// the channel's capacity.
+func (s *LedgerState) Run(ctx context.Context) error {
+	defer close(s.Entries)
+	return s.Walk(ctx, func(page []models.LedgerObject) error {
+		for _, entry := range page {
+			select {
+			case s.Entries <- entry:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+}
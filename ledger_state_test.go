@@ -0,0 +1,147 @@
+package xrpl_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xrpscan/xrpl-go"
+	"github.com/xrpscan/xrpl-go/models"
+	"github.com/xrpscan/xrpl-go/xrpltest"
+)
+
+// ledgerDataServer serves the state of ledger 1000 in three pages with two
+// entries each. The marker of page n+1 is n+1.
+func ledgerDataServer() *xrpltest.Server {
+	srv := xrpltest.NewServer()
+	srv.Handle("ledger_data", func(req xrpl.BaseRequest) (interface{}, error) {
+		marker, _ := req["marker"].(float64)
+		page := int(marker)
+		var state []interface{}
+		for i := 0; i < 2; i++ {
+			state = append(state, map[string]interface{}{
+				"LedgerEntryType": "AccountRoot",
+				"index":           fmt.Sprintf("ENTRY%d-%d", page, i),
+			})
+		}
+		result := map[string]interface{}{
+			"ledger_index": 1000,
+			"ledger_hash":  "LEDGER1000",
+			"state":        state,
+		}
+		if page < 2 {
+			result["marker"] = page + 1
+		}
+		return result, nil
+	})
+	return srv
+}
+
+func ledgerDataRequests(srv *xrpltest.Server) []xrpl.BaseRequest {
+	var requests []xrpl.BaseRequest
+	for _, req := range srv.Requests() {
+		if req["command"] == "ledger_data" {
+			requests = append(requests, req)
+		}
+	}
+	return requests
+}
+
+func TestLedgerStatePinsLedger(t *testing.T) {
+	srv := ledgerDataServer()
+	defer srv.Close()
+	client, err := xrpl.Dial(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	var progress []xrpl.LedgerStateProgress
+	state := xrpl.NewLedgerState(client, xrpl.LedgerStateConfig{
+		OnProgress: func(p xrpl.LedgerStateProgress) { progress = append(progress, p) },
+	})
+	var entries []string
+	err = state.Walk(context.Background(), func(page []models.LedgerObject) error {
+		for _, entry := range page {
+			entries = append(entries, entry.Index)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	if len(entries) != 6 || entries[0] != "ENTRY0-0" || entries[5] != "ENTRY2-1" {
+		t.Errorf("entries = %v", entries)
+	}
+
+	requests := ledgerDataRequests(srv)
+	if len(requests) != 3 {
+		t.Fatalf("%d ledger_data requests, want 3", len(requests))
+	}
+	if requests[0]["ledger_index"] != "validated" || requests[0]["ledger_hash"] != nil {
+		t.Errorf("first request = %v", requests[0])
+	}
+	for _, req := range requests[1:] {
+		if req["ledger_hash"] != "LEDGER1000" || req["ledger_index"] != nil {
+			t.Errorf("later request not pinned to the first page's ledger: %v", req)
+		}
+	}
+
+	if len(progress) != 3 {
+		t.Fatalf("%d progress reports, want 3", len(progress))
+	}
+	for i, p := range progress {
+		if p.Pages != i+1 || p.Entries != uint64(2*(i+1)) || p.LedgerIndex != 1000 || p.LedgerHash != "LEDGER1000" {
+			t.Errorf("progress[%d] = %+v", i, p)
+		}
+	}
+	if progress[0].Marker != float64(1) || progress[2].Marker != nil {
+		t.Errorf("markers = %v, %v", progress[0].Marker, progress[2].Marker)
+	}
+	if final := state.Progress(); final.Pages != 3 || final.Entries != 6 || final.Marker != nil {
+		t.Errorf("Progress() = %+v", final)
+	}
+}
+
+func TestLedgerStateRunCancel(t *testing.T) {
+	srv := ledgerDataServer()
+	defer srv.Close()
+	client, err := xrpl.Dial(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	state := xrpl.NewLedgerState(client, xrpl.LedgerStateConfig{QueueCapacity: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	var runErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		runErr = state.Run(ctx)
+	}()
+
+	// Nobody reads, so Run blocks once the channel is full
+	waitFor(t, "full Entries channel", func() bool { return len(state.Entries) == 1 })
+	cancel()
+	wg.Wait()
+	if !errors.Is(runErr, context.Canceled) {
+		t.Errorf("Run: err = %v, want context.Canceled", runErr)
+	}
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-state.Entries:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("Entries not closed")
+		}
+	}
+}
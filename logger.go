@@ -0,0 +1,59 @@
+package xrpl
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Logger receives the client's log output as a message and alternating
+// key/value pairs. *slog.Logger satisfies this interface.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// stdLogger is the default Logger. It writes warnings and errors to the
+// standard library logger and discards debug and info messages.
+type stdLogger struct{}
+
+func (stdLogger) Debug(msg string, args ...any) {}
+func (stdLogger) Info(msg string, args ...any)  {}
+func (stdLogger) Warn(msg string, args ...any)  { logPrint("WARN", msg, args) }
+func (stdLogger) Error(msg string, args ...any) { logPrint("ERROR", msg, args) }
+
+func logPrint(level, msg string, args []any) {
+	var b strings.Builder
+	b.WriteString(level)
+	b.WriteString(" ")
+	b.WriteString(msg)
+	for i := 0; i < len(args); i += 2 {
+		if i+1 < len(args) {
+			fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+		} else {
+			fmt.Fprintf(&b, " %v", args[i])
+		}
+	}
+	log.Println(b.String())
+}
+
+// Errors returns a channel of asynchronous failures that cannot be returned to
+// a caller, such as malformed messages and failed reconnection attempts.
+// Errors are dropped if the channel is full, so reading it is optional.
+//
+// The channel is never closed, not even by Close. Readers should also select
+// on Done to know when to stop.
+func (c *Client) Errors() <-chan error {
+	return c.errs
+}
+
+// reportError logs err and delivers it on the Errors channel without blocking.
+func (c *Client) reportError(msg string, err error, args ...any) {
+	c.log.Error(msg, append([]any{"url", c.config.URL, "error", err}, args...)...)
+	select {
+	case c.errs <- err:
+	default:
+	}
+}
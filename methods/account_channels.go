@@ -0,0 +1,51 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// The account_channels method returns information about an account's payment
+// channels. Expects a response in the form of an AccountChannelsResponse.
+type AccountChannelsRequest struct {
+	models.BaseRequest
+	Account            string                 `json:"account,omitempty"`
+	DestinationAccount string                 `json:"destination_account,omitempty"`
+	LedgerHash         string                 `json:"ledger_hash,omitempty"`
+	LedgerIndex        models.LedgerSpecifier `json:"ledger_index,omitempty"`
+	Limit              uint32                 `json:"limit,omitempty"`
+	Marker             interface{}            `json:"marker,omitempty"`
+}
+
+func (AccountChannelsRequest) Method() string {
+	return "account_channels"
+}
+
+type Channel struct {
+	Account            string `json:"account,omitempty"`
+	Amount             string `json:"amount,omitempty"`
+	Balance            string `json:"balance,omitempty"`
+	ChannelID          string `json:"channel_id,omitempty"`
+	DestinationAccount string `json:"destination_account,omitempty"`
+	SettleDelay        uint32 `json:"settle_delay,omitempty"`
+	PublicKey          string `json:"public_key,omitempty"`
+	PublicKeyHex       string `json:"public_key_hex,omitempty"`
+	Expiration         uint32 `json:"expiration,omitempty"`
+	CancelAfter        uint32 `json:"cancel_after,omitempty"`
+	SourceTag          uint32 `json:"source_tag,omitempty"`
+	DestinationTag     uint32 `json:"destination_tag,omitempty"`
+}
+
+// Response expected from an AccountChannelsRequest.
+type AccountChannelsResponse struct {
+	models.BaseResponse
+	Result AccountChannelsResult `json:"result,omitempty"`
+}
+
+type AccountChannelsResult struct {
+	Account            string      `json:"account,omitempty"`
+	Channels           []Channel   `json:"channels,omitempty"`
+	LedgerCurrentIndex int         `json:"ledger_current_index,omitempty"`
+	LedgerHash         string      `json:"ledger_hash,omitempty"`
+	LedgerIndex        int         `json:"ledger_index,omitempty"`
+	Limit              uint32      `json:"limit,omitempty"`
+	Marker             interface{} `json:"marker,omitempty"`
+	Validated          bool        `json:"validated,omitempty"`
+}
@@ -0,0 +1,33 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// The account_currencies method retrieves a list of currencies that an
+// account can send or receive, based on its trust lines. Expects a response
+// in the form of an AccountCurrenciesResponse.
+type AccountCurrenciesRequest struct {
+	models.BaseRequest
+	Account     string                 `json:"account,omitempty"`
+	LedgerHash  string                 `json:"ledger_hash,omitempty"`
+	LedgerIndex models.LedgerSpecifier `json:"ledger_index,omitempty"`
+	Strict      bool                   `json:"strict,omitempty"`
+}
+
+func (AccountCurrenciesRequest) Method() string {
+	return "account_currencies"
+}
+
+// Response expected from an AccountCurrenciesRequest.
+type AccountCurrenciesResponse struct {
+	models.BaseResponse
+	Result AccountCurrenciesResult `json:"result,omitempty"`
+}
+
+type AccountCurrenciesResult struct {
+	LedgerCurrentIndex int      `json:"ledger_current_index,omitempty"`
+	LedgerHash         string   `json:"ledger_hash,omitempty"`
+	LedgerIndex        int      `json:"ledger_index,omitempty"`
+	ReceiveCurrencies  []string `json:"receive_currencies,omitempty"`
+	SendCurrencies     []string `json:"send_currencies,omitempty"`
+	Validated          bool     `json:"validated,omitempty"`
+}
@@ -4,12 +4,16 @@ import "github.com/xrpscan/xrpl-go/models"
 
 type AccountInfoRequest struct {
 	models.BaseRequest
-	Account     string `json:"account,omitempty"`
-	LedgerHash  string `json:"ledger_hash,omitempty"`
-	LedgerIndex string `json:"ledger_index,omitempty"`
-	Queue       bool   `json:"queue,omitempty"`
-	SignerLists bool   `json:"signer_lists,omitempty"`
-	Strict      bool   `json:"strict,omitempty"`
+	Account     string                 `json:"account,omitempty"`
+	LedgerHash  string                 `json:"ledger_hash,omitempty"`
+	LedgerIndex models.LedgerSpecifier `json:"ledger_index,omitempty"`
+	Queue       bool                   `json:"queue,omitempty"`
+	SignerLists bool                   `json:"signer_lists,omitempty"`
+	Strict      bool                   `json:"strict,omitempty"`
+}
+
+func (AccountInfoRequest) Method() string {
+	return "account_info"
 }
 
 type QueueTransaction struct {
@@ -35,10 +39,11 @@ type AccountInfoResponse struct {
 }
 
 type AccountInfoResult struct {
-	AccountData        string    `json:"account_data,omitempty"` //todo
-	SignerLists        string    `json:"signer_lists,omitempty"` //todo
-	LedgerCurrentIndex int       `json:"ledger_current_index,omitempty"`
-	LedgerIndex        int       `json:"ledger_index,omitempty"`
-	QueueData          QueueData `json:"queue_data,omitempty"`
-	Validated          bool      `json:"validated,omitempty"`
+	AccountData        models.AccountRoot  `json:"account_data,omitempty"`
+	SignerLists        []models.SignerList `json:"signer_lists,omitempty"`
+	LedgerCurrentIndex int                 `json:"ledger_current_index,omitempty"`
+	LedgerHash         string              `json:"ledger_hash,omitempty"`
+	LedgerIndex        int                 `json:"ledger_index,omitempty"`
+	QueueData          QueueData           `json:"queue_data,omitempty"`
+	Validated          bool                `json:"validated,omitempty"`
 }
@@ -0,0 +1,52 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// The account_lines method returns information about an account's trust
+// lines. Expects a response in the form of an AccountLinesResponse.
+type AccountLinesRequest struct {
+	models.BaseRequest
+	Account     string                 `json:"account,omitempty"`
+	LedgerHash  string                 `json:"ledger_hash,omitempty"`
+	LedgerIndex models.LedgerSpecifier `json:"ledger_index,omitempty"`
+	Peer        string                 `json:"peer,omitempty"`
+	Limit       uint32                 `json:"limit,omitempty"`
+	Marker      interface{}            `json:"marker,omitempty"`
+}
+
+func (AccountLinesRequest) Method() string {
+	return "account_lines"
+}
+
+type TrustLine struct {
+	Account        string `json:"account,omitempty"`
+	Balance        string `json:"balance,omitempty"`
+	Currency       string `json:"currency,omitempty"`
+	Limit          string `json:"limit,omitempty"`
+	LimitPeer      string `json:"limit_peer,omitempty"`
+	QualityIn      uint32 `json:"quality_in,omitempty"`
+	QualityOut     uint32 `json:"quality_out,omitempty"`
+	NoRipple       bool   `json:"no_ripple,omitempty"`
+	NoRipplePeer   bool   `json:"no_ripple_peer,omitempty"`
+	Authorized     bool   `json:"authorized,omitempty"`
+	PeerAuthorized bool   `json:"peer_authorized,omitempty"`
+	Freeze         bool   `json:"freeze,omitempty"`
+	FreezePeer     bool   `json:"freeze_peer,omitempty"`
+}
+
+// Response expected from an AccountLinesRequest.
+type AccountLinesResponse struct {
+	models.BaseResponse
+	Result AccountLinesResult `json:"result,omitempty"`
+}
+
+type AccountLinesResult struct {
+	Account            string      `json:"account,omitempty"`
+	Lines              []TrustLine `json:"lines,omitempty"`
+	LedgerCurrentIndex int         `json:"ledger_current_index,omitempty"`
+	LedgerHash         string      `json:"ledger_hash,omitempty"`
+	LedgerIndex        int         `json:"ledger_index,omitempty"`
+	Limit              uint32      `json:"limit,omitempty"`
+	Marker             interface{} `json:"marker,omitempty"`
+	Validated          bool        `json:"validated,omitempty"`
+}
@@ -0,0 +1,45 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// The account_nfts method returns a list of NFToken objects for the specified
+// account. Expects a response in the form of an AccountNFTsResponse.
+type AccountNFTsRequest struct {
+	models.BaseRequest
+	Account     string                 `json:"account,omitempty"`
+	LedgerHash  string                 `json:"ledger_hash,omitempty"`
+	LedgerIndex models.LedgerSpecifier `json:"ledger_index,omitempty"`
+	Limit       uint32                 `json:"limit,omitempty"`
+	Marker      interface{}            `json:"marker,omitempty"`
+}
+
+func (AccountNFTsRequest) Method() string {
+	return "account_nfts"
+}
+
+type AccountNFT struct {
+	Flags        uint32 `json:"Flags"`
+	Issuer       string `json:"Issuer,omitempty"`
+	NFTokenID    string `json:"NFTokenID,omitempty"`
+	NFTokenTaxon uint32 `json:"NFTokenTaxon"`
+	URI          string `json:"URI,omitempty"`
+	NftSerial    uint32 `json:"nft_serial"`
+	TransferFee  uint16 `json:"TransferFee,omitempty"`
+}
+
+// Response expected from an AccountNFTsRequest.
+type AccountNFTsResponse struct {
+	models.BaseResponse
+	Result AccountNFTsResult `json:"result,omitempty"`
+}
+
+type AccountNFTsResult struct {
+	Account            string       `json:"account,omitempty"`
+	AccountNFTs        []AccountNFT `json:"account_nfts,omitempty"`
+	LedgerCurrentIndex int          `json:"ledger_current_index,omitempty"`
+	LedgerHash         string       `json:"ledger_hash,omitempty"`
+	LedgerIndex        int          `json:"ledger_index,omitempty"`
+	Limit              uint32       `json:"limit,omitempty"`
+	Marker             interface{}  `json:"marker,omitempty"`
+	Validated          bool         `json:"validated,omitempty"`
+}
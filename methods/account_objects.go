@@ -0,0 +1,38 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// The account_objects method returns the raw ledger format for all ledger
+// entries owned by an account. Expects a response in the form of an
+// AccountObjectsResponse.
+type AccountObjectsRequest struct {
+	models.BaseRequest
+	Account              string                 `json:"account,omitempty"`
+	DeletionBlockersOnly bool                   `json:"deletion_blockers_only,omitempty"`
+	LedgerHash           string                 `json:"ledger_hash,omitempty"`
+	LedgerIndex          models.LedgerSpecifier `json:"ledger_index,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Limit                uint32                 `json:"limit,omitempty"`
+	Marker               interface{}            `json:"marker,omitempty"`
+}
+
+func (AccountObjectsRequest) Method() string {
+	return "account_objects"
+}
+
+// Response expected from an AccountObjectsRequest.
+type AccountObjectsResponse struct {
+	models.BaseResponse
+	Result AccountObjectsResult `json:"result,omitempty"`
+}
+
+type AccountObjectsResult struct {
+	Account            string                `json:"account,omitempty"`
+	AccountObjects     []models.LedgerObject `json:"account_objects,omitempty"`
+	LedgerCurrentIndex int                   `json:"ledger_current_index,omitempty"`
+	LedgerHash         string                `json:"ledger_hash,omitempty"`
+	LedgerIndex        int                   `json:"ledger_index,omitempty"`
+	Limit              uint32                `json:"limit,omitempty"`
+	Marker             interface{}           `json:"marker,omitempty"`
+	Validated          bool                  `json:"validated,omitempty"`
+}
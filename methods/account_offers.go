@@ -0,0 +1,46 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// The account_offers method retrieves a list of offers made by a given
+// account that are outstanding as of a particular ledger version. Expects a
+// response in the form of an AccountOffersResponse.
+type AccountOffersRequest struct {
+	models.BaseRequest
+	Account     string                 `json:"account,omitempty"`
+	LedgerHash  string                 `json:"ledger_hash,omitempty"`
+	LedgerIndex models.LedgerSpecifier `json:"ledger_index,omitempty"`
+	Limit       uint32                 `json:"limit,omitempty"`
+	Marker      interface{}            `json:"marker,omitempty"`
+	Strict      bool                   `json:"strict,omitempty"`
+}
+
+func (AccountOffersRequest) Method() string {
+	return "account_offers"
+}
+
+type AccountOffer struct {
+	Flags      uint32        `json:"flags"`
+	Seq        uint32        `json:"seq,omitempty"`
+	TakerGets  models.Amount `json:"taker_gets,omitempty"`
+	TakerPays  models.Amount `json:"taker_pays,omitempty"`
+	Quality    string        `json:"quality,omitempty"`
+	Expiration uint32        `json:"expiration,omitempty"`
+}
+
+// Response expected from an AccountOffersRequest.
+type AccountOffersResponse struct {
+	models.BaseResponse
+	Result AccountOffersResult `json:"result,omitempty"`
+}
+
+type AccountOffersResult struct {
+	Account            string         `json:"account,omitempty"`
+	Offers             []AccountOffer `json:"offers,omitempty"`
+	LedgerCurrentIndex int            `json:"ledger_current_index,omitempty"`
+	LedgerHash         string         `json:"ledger_hash,omitempty"`
+	LedgerIndex        int            `json:"ledger_index,omitempty"`
+	Limit              uint32         `json:"limit,omitempty"`
+	Marker             interface{}    `json:"marker,omitempty"`
+	Validated          bool           `json:"validated,omitempty"`
+}
@@ -0,0 +1,96 @@
+package methods
+
+import (
+	"encoding/json"
+
+	"github.com/xrpscan/xrpl-go/models"
+)
+
+// The account_tx method retrieves a list of transactions that involved the
+// specified account. Expects a response in the form of an AccountTxResponse.
+type AccountTxRequest struct {
+	models.BaseRequest
+	Account        string                 `json:"account,omitempty"`
+	LedgerIndexMin int64                  `json:"ledger_index_min,omitempty"`
+	LedgerIndexMax int64                  `json:"ledger_index_max,omitempty"`
+	LedgerHash     string                 `json:"ledger_hash,omitempty"`
+	LedgerIndex    models.LedgerSpecifier `json:"ledger_index,omitempty"`
+	Binary         bool                   `json:"binary,omitempty"`
+	Forward        bool                   `json:"forward,omitempty"`
+	Limit          uint32                 `json:"limit,omitempty"`
+	Marker         interface{}            `json:"marker,omitempty"`
+}
+
+func (AccountTxRequest) Method() string {
+	return "account_tx"
+}
+
+// Response expected from an AccountTxRequest.
+type AccountTxResponse struct {
+	models.BaseResponse
+	Result AccountTxResult `json:"result,omitempty"`
+}
+
+type AccountTxResult struct {
+	Account        string               `json:"account,omitempty"`
+	LedgerIndexMin int64                `json:"ledger_index_min,omitempty"`
+	LedgerIndexMax int64                `json:"ledger_index_max,omitempty"`
+	Limit          uint32               `json:"limit,omitempty"`
+	Marker         interface{}          `json:"marker,omitempty"`
+	Transactions   []AccountTransaction `json:"transactions,omitempty"`
+	Validated      bool                 `json:"validated,omitempty"`
+}
+
+// AccountTransaction is an entry of an account_tx result. With api_version 2
+// rippled sends the transaction as tx_json; it is decoded into Transaction
+// either way. In binary mode only TxBlob and MetaBlob are set.
+type AccountTransaction struct {
+	Hash         string                     `json:"hash,omitempty"`
+	LedgerIndex  uint64                     `json:"ledger_index,omitempty"`
+	CloseTimeIso string                     `json:"close_time_iso,omitempty"`
+	Transaction  models.Transaction         `json:"tx,omitempty"`
+	TxJson       *models.Transaction        `json:"tx_json,omitempty"`
+	Meta         models.TransactionMetadata `json:"meta,omitempty"`
+	TxBlob       string                     `json:"tx_blob,omitempty"`
+	MetaBlob     string                     `json:"meta_blob,omitempty"`
+	Validated    bool                       `json:"validated,omitempty"`
+}
+
+func (t *AccountTransaction) UnmarshalJSON(data []byte) error {
+	type accountTransaction AccountTransaction
+	var v struct {
+		accountTransaction
+		Meta json.RawMessage `json:"meta,omitempty"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	tx := AccountTransaction(v.accountTransaction)
+
+	// Binary metadata is a hex string under meta_blob, or under meta before
+	// api_version 2
+	if len(v.Meta) > 0 && v.Meta[0] == '"' {
+		if err := json.Unmarshal(v.Meta, &tx.MetaBlob); err != nil {
+			return err
+		}
+	} else if len(v.Meta) > 0 {
+		if err := json.Unmarshal(v.Meta, &tx.Meta); err != nil {
+			return err
+		}
+	}
+
+	if tx.TxJson != nil {
+		tx.Transaction = *tx.TxJson
+	}
+	if tx.Transaction.Amount.Value == "" {
+		tx.Transaction.Amount = tx.Transaction.DeliverMax
+	}
+	if tx.Hash == "" {
+		tx.Hash = tx.Transaction.Hash
+	}
+	if tx.LedgerIndex == 0 {
+		tx.LedgerIndex = uint64(tx.Transaction.LedgerIndex)
+	}
+	*t = tx
+	return nil
+}
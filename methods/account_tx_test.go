@@ -0,0 +1,41 @@
+package methods
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAccountTransactionV2Payment(t *testing.T) {
+	data := []byte(`{
+		"hash": "E08D6E9754025BA2534A78707605E0601F03ACE063687A0CA1BDDACFCD1698C7",
+		"ledger_index": 85000000,
+		"validated": true,
+		"tx_json": {
+			"TransactionType": "Payment",
+			"Account": "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn",
+			"DeliverMax": "1000"
+		},
+		"meta": {"TransactionResult": "tesSUCCESS"}
+	}`)
+	var tx AccountTransaction
+	if err := json.Unmarshal(data, &tx); err != nil {
+		t.Fatal(err)
+	}
+	if tx.Transaction.Amount.Value != "1000" {
+		t.Errorf("Amount = %+v, want 1000 drops", tx.Transaction.Amount)
+	}
+	if tx.Meta.TransactionResult != "tesSUCCESS" {
+		t.Errorf("meta not decoded: %+v", tx.Meta)
+	}
+}
+
+func TestAccountTransactionBinary(t *testing.T) {
+	data := []byte(`{"ledger_index": 85000000, "tx_blob": "1200", "meta": "201C"}`)
+	var tx AccountTransaction
+	if err := json.Unmarshal(data, &tx); err != nil {
+		t.Fatal(err)
+	}
+	if tx.TxBlob != "1200" || tx.MetaBlob != "201C" {
+		t.Errorf("TxBlob = %q, MetaBlob = %q", tx.TxBlob, tx.MetaBlob)
+	}
+}
@@ -0,0 +1,51 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// The gateway_balances method calculates the total balances issued by a given
+// account, optionally excluding amounts held by operational addresses.
+// Expects a response in the form of a GatewayBalancesResponse.
+type GatewayBalancesRequest struct {
+	models.BaseRequest
+	Account     string                 `json:"account,omitempty"`
+	HotWallet   []string               `json:"hotwallet,omitempty"`
+	LedgerHash  string                 `json:"ledger_hash,omitempty"`
+	LedgerIndex models.LedgerSpecifier `json:"ledger_index,omitempty"`
+	Strict      bool                   `json:"strict,omitempty"`
+}
+
+func (GatewayBalancesRequest) Method() string {
+	return "gateway_balances"
+}
+
+type CurrencyBalance struct {
+	Currency string `json:"currency,omitempty"`
+	Value    string `json:"value,omitempty"`
+}
+
+// Response expected from a GatewayBalancesRequest.
+type GatewayBalancesResponse struct {
+	models.BaseResponse
+	Result GatewayBalancesResult `json:"result,omitempty"`
+}
+
+type GatewayBalancesResult struct {
+	Account string `json:"account,omitempty"`
+
+	// Total amounts issued, by currency
+	Obligations map[string]string `json:"obligations,omitempty"`
+
+	// Amounts held by the hot wallets, by hot wallet address
+	Balances map[string][]CurrencyBalance `json:"balances,omitempty"`
+
+	// Amounts issued to the account by others, by issuer address
+	Assets map[string][]CurrencyBalance `json:"assets,omitempty"`
+
+	// Frozen amounts issued by the account, by holder address
+	FrozenBalances map[string][]CurrencyBalance `json:"frozen_balances,omitempty"`
+
+	LedgerCurrentIndex int    `json:"ledger_current_index,omitempty"`
+	LedgerHash         string `json:"ledger_hash,omitempty"`
+	LedgerIndex        int    `json:"ledger_index,omitempty"`
+	Validated          bool   `json:"validated,omitempty"`
+}
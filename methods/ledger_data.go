@@ -0,0 +1,34 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// The ledger_data method retrieves contents of the specified ledger. You can
+// iterate through several calls to retrieve the entire contents of a single
+// ledger version. Expects a response in the form of a LedgerDataResponse.
+type LedgerDataRequest struct {
+	models.BaseRequest
+	LedgerHash  string                 `json:"ledger_hash,omitempty"`
+	LedgerIndex models.LedgerSpecifier `json:"ledger_index,omitempty"`
+	Binary      bool                   `json:"binary,omitempty"`
+	Limit       uint32                 `json:"limit,omitempty"`
+	Marker      interface{}            `json:"marker,omitempty"`
+	Type        string                 `json:"type,omitempty"`
+}
+
+func (LedgerDataRequest) Method() string {
+	return "ledger_data"
+}
+
+// Response expected from a LedgerDataRequest.
+type LedgerDataResponse struct {
+	models.BaseResponse
+	Result LedgerDataResult `json:"result,omitempty"`
+}
+
+type LedgerDataResult struct {
+	LedgerHash  string                 `json:"ledger_hash,omitempty"`
+	LedgerIndex models.LedgerSpecifier `json:"ledger_index,omitempty"`
+	State       []models.LedgerObject  `json:"state,omitempty"`
+	Marker      interface{}            `json:"marker,omitempty"`
+	Validated   bool                   `json:"validated,omitempty"`
+}
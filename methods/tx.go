@@ -12,6 +12,10 @@ type TxRequest struct {
 	MaxLedger   int64  `json:"max_ledger,omitempty"`
 }
 
+func (TxRequest) Method() string {
+	return "tx"
+}
+
 // Response expected from a TxRequest.
 type TxResponse struct {
 	models.BaseResponse
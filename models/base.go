@@ -24,11 +24,12 @@ type BaseResponse struct {
 }
 
 type ErrorResponse struct {
-	Id           int    `json:"id,omitempty"`
-	Status       string `json:"status,omitempty"`
-	Type         string `json:"type,omitempty"`
-	Error        string `json:"error,omitempty"`
-	ErrorCode    string `json:"error_code,omitempty"`
-	ErrorMessage string `json:"error_message,omitempty"`
-	ApiVersion   int16  `json:"api_version,omitempty"`
+	Id           string                 `json:"id,omitempty"`
+	Status       string                 `json:"status,omitempty"`
+	Type         string                 `json:"type,omitempty"`
+	Error        string                 `json:"error,omitempty"`
+	ErrorCode    int                    `json:"error_code,omitempty"`
+	ErrorMessage string                 `json:"error_message,omitempty"`
+	Request      map[string]interface{} `json:"request,omitempty"`
+	ApiVersion   int16                  `json:"api_version,omitempty"`
 }
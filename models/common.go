@@ -1,7 +1,55 @@
 package models
 
+import (
+	"encoding/json"
+	"strconv"
+)
+
 type LedgerIndex int
 
+// LedgerSpecifier selects a ledger either by sequence number or by one of the
+// shortcuts "validated", "closed" and "current". Sequence numbers are encoded
+// in JSON as numbers, shortcuts as strings.
+type LedgerSpecifier string
+
+const (
+	LedgerValidated LedgerSpecifier = "validated"
+	LedgerClosed    LedgerSpecifier = "closed"
+	LedgerCurrent   LedgerSpecifier = "current"
+)
+
+// LedgerSeq returns the specifier of the ledger with sequence number seq.
+func LedgerSeq(seq uint32) LedgerSpecifier {
+	return LedgerSpecifier(strconv.FormatUint(uint64(seq), 10))
+}
+
+// Seq returns the ledger sequence number of s, if s is not a shortcut.
+func (s LedgerSpecifier) Seq() (uint32, bool) {
+	seq, err := strconv.ParseUint(string(s), 10, 32)
+	return uint32(seq), err == nil
+}
+
+func (s LedgerSpecifier) MarshalJSON() ([]byte, error) {
+	if seq, ok := s.Seq(); ok {
+		return json.Marshal(seq)
+	}
+	return json.Marshal(string(s))
+}
+
+func (s *LedgerSpecifier) UnmarshalJSON(data []byte) error {
+	var seq uint32
+	if err := json.Unmarshal(data, &seq); err == nil {
+		*s = LedgerSeq(seq)
+		return nil
+	}
+	var shortcut string
+	if err := json.Unmarshal(data, &shortcut); err != nil {
+		return err
+	}
+	*s = LedgerSpecifier(shortcut)
+	return nil
+}
+
 type Currency struct {
 	Currency string `json:"currency,omitempty"`
 }
@@ -16,26 +64,75 @@ type IssuedCurrencyAmount struct {
 	Value          string `json:"value,omitempty"`
 }
 
+// Amounts of issued currencies are JSON objects with currency, issuer and
+// value fields.
+func (a *IssuedCurrencyAmount) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Currency string `json:"currency"`
+		Issuer   string `json:"issuer"`
+		Value    string `json:"value"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	a.Currency.Currency = v.Currency
+	a.Issuer = v.Issuer
+	a.Value = v.Value
+	return nil
+}
+
+func (a IssuedCurrencyAmount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Currency string `json:"currency"`
+		Issuer   string `json:"issuer,omitempty"`
+		Value    string `json:"value"`
+	}{a.Currency.Currency, a.Issuer, a.Value})
+}
+
+// Amount is either an amount of the native asset, encoded in JSON as a string
+// of drops, or an issued currency amount. For native amounts only Value is
+// set.
 type Amount IssuedCurrencyAmount
 
+// IsNative reports whether a is an amount of the network's native asset.
+func (a Amount) IsNative() bool {
+	return a.Currency.Currency == "" && a.Issuer == ""
+}
+
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	var drops string
+	if err := json.Unmarshal(data, &drops); err == nil {
+		*a = Amount{Value: drops}
+		return nil
+	}
+	return (*IssuedCurrencyAmount)(a).UnmarshalJSON(data)
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	if a.IsNative() {
+		return json.Marshal(a.Value)
+	}
+	return IssuedCurrencyAmount(a).MarshalJSON()
+}
+
 type Signer struct {
-	Signer SignerMap `json:"signer,omitempty"`
+	Signer SignerMap `json:"Signer,omitempty"`
 }
 
 type SignerMap struct {
-	Account       string `json:"account,omitempty"`
-	TxnSignature  string `json:"txn_signature,omitempty"`
-	SigningPubKey string `json:"signing_pub_key,omitempty"`
+	Account       string `json:"Account,omitempty"`
+	TxnSignature  string `json:"TxnSignature,omitempty"`
+	SigningPubKey string `json:"SigningPubKey,omitempty"`
 }
 
 type Memo struct {
-	Memo MemoMap `json:"memo,omitempty"`
+	Memo MemoMap `json:"Memo,omitempty"`
 }
 
 type MemoMap struct {
-	MemoData   string `json:"memo_data,omitempty"`
-	MemoType   string `json:"memo_type,omitempty"`
-	MemoFormat string `json:"memo_format,omitempty"`
+	MemoData   string `json:"MemoData,omitempty"`
+	MemoType   string `json:"MemoType,omitempty"`
+	MemoFormat string `json:"MemoFormat,omitempty"`
 }
 
 type StreamType string
@@ -49,13 +146,13 @@ type PathStep struct {
 type Path []PathStep
 
 type SignerEntry struct {
-	SignerEntry SignerEntryMap `json:"signer_entry,omitempty"`
+	SignerEntry SignerEntryMap `json:"SignerEntry,omitempty"`
 }
 
 type SignerEntryMap struct {
-	Account       string `json:"account,omitempty"`
-	SignerWeight  int16  `json:"signer_weight,omitempty"`
-	WalletLocator string `json:"wallet_locator,omitempty"`
+	Account       string `json:"Account,omitempty"`
+	SignerWeight  int16  `json:"SignerWeight,omitempty"`
+	WalletLocator string `json:"WalletLocator,omitempty"`
 }
 
 type ResponseOnlyTxInfo struct {
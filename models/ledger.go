@@ -13,6 +13,10 @@ type LedgerRequest struct {
 	Queue        bool        `json:"queue,omitempty"`
 }
 
+func (LedgerRequest) Method() string {
+	return "ledger"
+}
+
 // type ModifiedMetadata struct {
 // 	TransactionMetadata
 // 	OwnerFunds string
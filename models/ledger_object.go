@@ -0,0 +1,329 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// The AccountRoot ledger entry type describes a single account, its settings,
+// and XRP balance.
+//
+// LedgerEntryType: 'AccountRoot'
+type AccountRoot struct {
+	LedgerEntryType      string
+	Account              string
+	Balance              string
+	Flags                uint32
+	OwnerCount           uint32
+	PreviousTxnID        string
+	PreviousTxnLgrSeq    uint32
+	Sequence             uint32
+	AccountTxnID         string `json:",omitempty"`
+	AMMID                string `json:",omitempty"`
+	BurnedNFTokens       uint32 `json:",omitempty"`
+	Domain               string `json:",omitempty"`
+	EmailHash            string `json:",omitempty"`
+	FirstNFTokenSequence uint32 `json:",omitempty"`
+	MessageKey           string `json:",omitempty"`
+	MintedNFTokens       uint32 `json:",omitempty"`
+	NFTokenMinter        string `json:",omitempty"`
+	RegularKey           string `json:",omitempty"`
+	TicketCount          uint32 `json:",omitempty"`
+	TickSize             uint8  `json:",omitempty"`
+	TransferRate         uint32 `json:",omitempty"`
+	WalletLocator        string `json:",omitempty"`
+	Index                string `json:"index,omitempty"`
+}
+
+// The SignerList ledger entry type represents a list of parties that, as a
+// group, are authorized to sign a transaction in place of an individual
+// account.
+//
+// LedgerEntryType: 'SignerList'
+type SignerList struct {
+	LedgerEntryType   string
+	Flags             uint32
+	OwnerNode         string
+	PreviousTxnID     string
+	PreviousTxnLgrSeq uint32
+	SignerEntries     []SignerEntry
+	SignerListID      uint32
+	SignerQuorum      uint32
+	Index             string `json:"index,omitempty"`
+}
+
+// The RippleState ledger entry type represents a trust line between two
+// accounts.
+//
+// LedgerEntryType: 'RippleState'
+type RippleState struct {
+	LedgerEntryType   string
+	Balance           IssuedCurrencyAmount
+	Flags             uint32
+	HighLimit         IssuedCurrencyAmount
+	HighNode          string
+	HighQualityIn     uint32 `json:",omitempty"`
+	HighQualityOut    uint32 `json:",omitempty"`
+	LowLimit          IssuedCurrencyAmount
+	LowNode           string
+	LowQualityIn      uint32 `json:",omitempty"`
+	LowQualityOut     uint32 `json:",omitempty"`
+	PreviousTxnID     string
+	PreviousTxnLgrSeq uint32
+	Index             string `json:"index,omitempty"`
+}
+
+// The Offer ledger entry type describes an offer to exchange currencies in
+// the decentralized exchange.
+//
+// LedgerEntryType: 'Offer'
+type Offer struct {
+	LedgerEntryType   string
+	Account           string
+	BookDirectory     string
+	BookNode          string
+	Expiration        uint32 `json:",omitempty"`
+	Flags             uint32
+	OwnerNode         string
+	PreviousTxnID     string
+	PreviousTxnLgrSeq uint32
+	Sequence          uint32
+	TakerPays         Amount
+	TakerGets         Amount
+	Index             string `json:"index,omitempty"`
+}
+
+// The PayChannel ledger entry type represents a payment channel.
+//
+// LedgerEntryType: 'PayChannel'
+type PayChannel struct {
+	LedgerEntryType   string
+	Account           string
+	Amount            string
+	Balance           string
+	CancelAfter       uint32 `json:",omitempty"`
+	Destination       string
+	DestinationTag    uint32 `json:",omitempty"`
+	DestinationNode   string `json:",omitempty"`
+	Expiration        uint32 `json:",omitempty"`
+	Flags             uint32
+	OwnerNode         string
+	PreviousTxnID     string
+	PreviousTxnLgrSeq uint32
+	PublicKey         string
+	SettleDelay       uint32
+	SourceTag         uint32 `json:",omitempty"`
+	Index             string `json:"index,omitempty"`
+}
+
+// The Check ledger entry type represents a check that can be cashed by its
+// destination.
+//
+// LedgerEntryType: 'Check'
+type Check struct {
+	LedgerEntryType   string
+	Account           string
+	Destination       string
+	DestinationNode   string `json:",omitempty"`
+	DestinationTag    uint32 `json:",omitempty"`
+	Expiration        uint32 `json:",omitempty"`
+	Flags             uint32
+	InvoiceID         string `json:",omitempty"`
+	OwnerNode         string
+	PreviousTxnID     string
+	PreviousTxnLgrSeq uint32
+	SendMax           Amount
+	Sequence          uint32
+	SourceTag         uint32 `json:",omitempty"`
+	Index             string `json:"index,omitempty"`
+}
+
+// The Escrow ledger entry type represents held payment of XRP waiting to be
+// executed or canceled.
+//
+// LedgerEntryType: 'Escrow'
+type Escrow struct {
+	LedgerEntryType   string
+	Account           string
+	Amount            string
+	CancelAfter       uint32 `json:",omitempty"`
+	Condition         string `json:",omitempty"`
+	Destination       string
+	DestinationNode   string `json:",omitempty"`
+	DestinationTag    uint32 `json:",omitempty"`
+	FinishAfter       uint32 `json:",omitempty"`
+	Flags             uint32
+	OwnerNode         string
+	PreviousTxnID     string
+	PreviousTxnLgrSeq uint32
+	SourceTag         uint32 `json:",omitempty"`
+	Index             string `json:"index,omitempty"`
+}
+
+// The DepositPreauth ledger entry type tracks a preauthorization from one
+// account to another.
+//
+// LedgerEntryType: 'DepositPreauth'
+type DepositPreauth struct {
+	LedgerEntryType   string
+	Account           string
+	Authorize         string
+	Flags             uint32
+	OwnerNode         string
+	PreviousTxnID     string
+	PreviousTxnLgrSeq uint32
+	Index             string `json:"index,omitempty"`
+}
+
+// The Ticket ledger entry type represents a sequence number set aside for
+// future use.
+//
+// LedgerEntryType: 'Ticket'
+type Ticket struct {
+	LedgerEntryType   string
+	Account           string
+	Flags             uint32
+	OwnerNode         string
+	PreviousTxnID     string
+	PreviousTxnLgrSeq uint32
+	TicketSequence    uint32
+	Index             string `json:"index,omitempty"`
+}
+
+type NFToken struct {
+	NFToken NFTokenMap
+}
+
+type NFTokenMap struct {
+	NFTokenID string
+	URI       string `json:",omitempty"`
+}
+
+// The NFTokenPage ledger entry type represents a collection of NFTs owned by
+// the same account.
+//
+// LedgerEntryType: 'NFTokenPage'
+type NFTokenPage struct {
+	LedgerEntryType   string
+	NextPageMin       string `json:",omitempty"`
+	NFTokens          []NFToken
+	PreviousPageMin   string `json:",omitempty"`
+	PreviousTxnID     string
+	PreviousTxnLgrSeq uint32
+	Index             string `json:"index,omitempty"`
+}
+
+// The NFTokenOffer ledger entry type represents an offer to buy, sell or
+// transfer an NFT.
+//
+// LedgerEntryType: 'NFTokenOffer'
+type NFTokenOffer struct {
+	LedgerEntryType   string
+	Amount            Amount
+	Destination       string `json:",omitempty"`
+	Expiration        uint32 `json:",omitempty"`
+	Flags             uint32
+	NFTokenID         string
+	NFTokenOfferNode  string `json:",omitempty"`
+	Owner             string
+	OwnerNode         string
+	PreviousTxnID     string
+	PreviousTxnLgrSeq uint32
+	Index             string `json:"index,omitempty"`
+}
+
+// The DirectoryNode ledger entry type represents a page of an owner or offer
+// directory.
+//
+// LedgerEntryType: 'DirectoryNode'
+type DirectoryNode struct {
+	LedgerEntryType   string
+	Flags             uint32
+	Indexes           []string
+	IndexNext         string `json:",omitempty"`
+	IndexPrevious     string `json:",omitempty"`
+	Owner             string `json:",omitempty"`
+	RootIndex         string
+	TakerGetsCurrency string `json:",omitempty"`
+	TakerGetsIssuer   string `json:",omitempty"`
+	TakerPaysCurrency string `json:",omitempty"`
+	TakerPaysIssuer   string `json:",omitempty"`
+	Index             string `json:"index,omitempty"`
+}
+
+// LedgerObject is a ledger entry of any type, as returned by account_objects
+// or ledger_data. The raw JSON is kept so that it can be decoded into its
+// typed model with Decode. Entries returned in binary mode only have Index
+// and Data, the hex encoded entry, set.
+type LedgerObject struct {
+	LedgerEntryType string
+	Index           string
+	Data            string
+	Raw             json.RawMessage
+}
+
+func (o *LedgerObject) UnmarshalJSON(data []byte) error {
+	var header struct {
+		LedgerEntryType string
+		Index           string `json:"index"`
+		Data            string `json:"data"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+	o.LedgerEntryType = header.LedgerEntryType
+	o.Index = header.Index
+	o.Data = header.Data
+	o.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+func (o LedgerObject) MarshalJSON() ([]byte, error) {
+	if o.Raw == nil {
+		return []byte("null"), nil
+	}
+	return o.Raw, nil
+}
+
+// Decode returns the ledger entry as a pointer to its typed model, e.g.
+// *RippleState for a trust line. Entry types without a model are returned as
+// map[string]interface{}.
+func (o LedgerObject) Decode() (interface{}, error) {
+	var v interface{}
+	switch o.LedgerEntryType {
+	case "AccountRoot":
+		v = &AccountRoot{}
+	case "Check":
+		v = &Check{}
+	case "DepositPreauth":
+		v = &DepositPreauth{}
+	case "DirectoryNode":
+		v = &DirectoryNode{}
+	case "Escrow":
+		v = &Escrow{}
+	case "NFTokenOffer":
+		v = &NFTokenOffer{}
+	case "NFTokenPage":
+		v = &NFTokenPage{}
+	case "Offer":
+		v = &Offer{}
+	case "PayChannel":
+		v = &PayChannel{}
+	case "RippleState":
+		v = &RippleState{}
+	case "SignerList":
+		v = &SignerList{}
+	case "Ticket":
+		v = &Ticket{}
+	default:
+		m := map[string]interface{}{}
+		if err := json.Unmarshal(o.Raw, &m); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+	if err := json.Unmarshal(o.Raw, v); err != nil {
+		return nil, fmt.Errorf("cannot decode %s %s: %w", o.LedgerEntryType, o.Index, err)
+	}
+	return v, nil
+}
@@ -1,5 +1,7 @@
 package models
 
+import "encoding/json"
+
 type LedgerStream struct {
 	Type             string `json:"type,omitempty"` // default: ledgerClosed
 	FeeBase          uint64 `json:"fee_base,omitempty"`
@@ -32,20 +34,45 @@ type ValidationStream struct {
 	ValidationPublicKey string   `json:"validation_public_key,omitempty"`
 }
 
+// TransactionStream is a message of the transactions, transactions_proposed,
+// accounts and books streams. With api_version 2 rippled sends the
+// transaction as tx_json; it is decoded into Transaction either way.
 type TransactionStream struct {
-	Type                string `json:"type,omitempty"` // default: transaction
-	Status              string `json:"status,omitempty"`
-	EngineResult        string `json:"engine_result,omitempty"`
-	EngineResultCode    int64  `json:"engine_result_code,omitempty"`
-	EngineResultMessage string `json:"engine_result_message,omitempty"`
-	LedgerCurrentIndex  uint64 `json:"ledger_current_index,omitempty"`
-	LedgerHash          string `json:"ledger_hash,omitempty"`
-	LedgerIndex         uint64 `json:"ledger_index,omitempty"`
-	Meta                string `json:"meta,omitempty"`
-	Transaction         string `json:"transaction,omitempty"`
-	Validated           bool   `json:"validated,omitempty"`
-	// Meta                TransactionMeta `json:"meta,omitempty"`
-	// Transaction         Transaction     `json:"transaction,omitempty"`
+	Type                string              `json:"type,omitempty"` // default: transaction
+	Status              string              `json:"status,omitempty"`
+	EngineResult        string              `json:"engine_result,omitempty"`
+	EngineResultCode    int64               `json:"engine_result_code,omitempty"`
+	EngineResultMessage string              `json:"engine_result_message,omitempty"`
+	LedgerCurrentIndex  uint64              `json:"ledger_current_index,omitempty"`
+	LedgerHash          string              `json:"ledger_hash,omitempty"`
+	LedgerIndex         uint64              `json:"ledger_index,omitempty"`
+	CloseTimeIso        string              `json:"close_time_iso,omitempty"`
+	Hash                string              `json:"hash,omitempty"`
+	Ctid                string              `json:"ctid,omitempty"`
+	Meta                TransactionMetadata `json:"meta,omitempty"`
+	Transaction         Transaction         `json:"transaction,omitempty"`
+	TxJson              *Transaction        `json:"tx_json,omitempty"`
+	Validated           bool                `json:"validated,omitempty"`
+	ApiVersion          int16               `json:"api_version,omitempty"`
+}
+
+func (s *TransactionStream) UnmarshalJSON(data []byte) error {
+	type transactionStream TransactionStream
+	var v transactionStream
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.TxJson != nil {
+		v.Transaction = *v.TxJson
+	}
+	if v.Transaction.Amount.Value == "" {
+		v.Transaction.Amount = v.Transaction.DeliverMax
+	}
+	if v.Hash == "" {
+		v.Hash = v.Transaction.Hash
+	}
+	*s = TransactionStream(v)
+	return nil
 }
 
 type PeerStatusStream struct {
@@ -58,18 +85,50 @@ type PeerStatusStream struct {
 	LedgerIndexMin uint64 `json:"ledger_index_min,omitempty"`
 }
 
-type OrderBookStream struct {
-	Type                string `json:"type,omitempty"` // default: transaction
-	Status              string `json:"status,omitempty"`
-	EngineResult        string `json:"engine_result,omitempty"`
-	EngineResultCode    int64  `json:"engine_result_code,omitempty"`
-	EngineResultMessage string `json:"engine_result_message,omitempty"`
-	LedgerCurrentIndex  uint64 `json:"ledger_current_index,omitempty"`
-	LedgerHash          string `json:"ledger_hash,omitempty"`
-	LedgerIndex         uint64 `json:"ledger_index,omitempty"`
-	Meta                string `json:"meta,omitempty"`
-	Transaction         string `json:"transaction,omitempty"`
-	Validated           bool   `json:"validated,omitempty"`
+// OrderBookStream is a message of the books stream. It has the same format as
+// a transactions stream message.
+type OrderBookStream = TransactionStream
+
+type ServerStatusStream struct {
+	Type                    string `json:"type,omitempty"` // default: serverStatus
+	BaseFee                 uint64 `json:"base_fee,omitempty"`
+	LoadBase                uint64 `json:"load_base,omitempty"`
+	LoadFactor              uint64 `json:"load_factor,omitempty"`
+	LoadFactorFeeEscalation uint64 `json:"load_factor_fee_escalation,omitempty"`
+	LoadFactorFeeQueue      uint64 `json:"load_factor_fee_queue,omitempty"`
+	LoadFactorFeeReference  uint64 `json:"load_factor_fee_reference,omitempty"`
+	LoadFactorServer        uint64 `json:"load_factor_server,omitempty"`
+	ServerStatus            string `json:"server_status,omitempty"`
+}
+
+type ManifestStream struct {
+	Type            string `json:"type,omitempty"` // default: manifestReceived
+	Domain          string `json:"domain,omitempty"`
+	Manifest        string `json:"manifest,omitempty"`
+	MasterKey       string `json:"master_key,omitempty"`
+	MasterSignature string `json:"master_signature,omitempty"`
+	Seq             uint64 `json:"seq,omitempty"`
+	Signature       string `json:"signature,omitempty"`
+	SigningKey      string `json:"signing_key,omitempty"`
+}
+
+type BookChange struct {
+	CurrencyA string `json:"currency_a,omitempty"`
+	CurrencyB string `json:"currency_b,omitempty"`
+	VolumeA   string `json:"volume_a,omitempty"`
+	VolumeB   string `json:"volume_b,omitempty"`
+	High      string `json:"high,omitempty"`
+	Low       string `json:"low,omitempty"`
+	Open      string `json:"open,omitempty"`
+	Close     string `json:"close,omitempty"`
+}
+
+type BookChangesStream struct {
+	Type        string       `json:"type,omitempty"` // default: bookChanges
+	LedgerHash  string       `json:"ledger_hash,omitempty"`
+	LedgerIndex uint64       `json:"ledger_index,omitempty"`
+	LedgerTime  uint64       `json:"ledger_time,omitempty"`
+	Changes     []BookChange `json:"changes,omitempty"`
 }
 
 type ConsensusStream struct {
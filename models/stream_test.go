@@ -0,0 +1,54 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTransactionStreamV2Payment(t *testing.T) {
+	data := []byte(`{
+		"type": "transaction",
+		"hash": "E08D6E9754025BA2534A78707605E0601F03ACE063687A0CA1BDDACFCD1698C7",
+		"ledger_index": 85000000,
+		"validated": true,
+		"tx_json": {
+			"TransactionType": "Payment",
+			"Account": "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn",
+			"Destination": "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe",
+			"DeliverMax": "1000"
+		},
+		"meta": {"TransactionResult": "tesSUCCESS", "delivered_amount": "1000"}
+	}`)
+	var tx TransactionStream
+	if err := json.Unmarshal(data, &tx); err != nil {
+		t.Fatal(err)
+	}
+	if tx.Transaction.TransactionType != "Payment" || tx.Transaction.Destination != "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe" {
+		t.Errorf("tx_json not decoded: %+v", tx.Transaction)
+	}
+	if !tx.Transaction.Amount.IsNative() || tx.Transaction.Amount.Value != "1000" {
+		t.Errorf("Amount = %+v, want 1000 drops", tx.Transaction.Amount)
+	}
+}
+
+func TestTransactionStreamV1Payment(t *testing.T) {
+	data := []byte(`{
+		"type": "transaction",
+		"transaction": {
+			"TransactionType": "Payment",
+			"Amount": {"currency": "USD", "issuer": "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "value": "5"},
+			"DeliverMax": {"currency": "USD", "issuer": "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "value": "5"},
+			"hash": "E08D6E9754025BA2534A78707605E0601F03ACE063687A0CA1BDDACFCD1698C7"
+		}
+	}`)
+	var tx TransactionStream
+	if err := json.Unmarshal(data, &tx); err != nil {
+		t.Fatal(err)
+	}
+	if tx.Transaction.Amount.Value != "5" || tx.Transaction.Amount.IsNative() {
+		t.Errorf("Amount = %+v, want 5 USD", tx.Transaction.Amount)
+	}
+	if tx.Hash != "E08D6E9754025BA2534A78707605E0601F03ACE063687A0CA1BDDACFCD1698C7" {
+		t.Errorf("Hash = %q", tx.Hash)
+	}
+}
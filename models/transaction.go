@@ -35,7 +35,6 @@ type TransactionPayment struct {
 	Paths          []Path
 	SendMax        Amount
 	DeliverMin     Amount
-	Flags          int64
 }
 
 type PaymentFlags struct {
@@ -63,7 +62,6 @@ type TransactionNFTokenAcceptOffer struct {
 // TransactionType: 'NFTokenBurn'
 type TransactionNFTokenBurn struct {
 	BaseTransaction
-	Account   string
 	NFTokenID string
 	Owner     string
 }
@@ -90,7 +88,6 @@ type TransactionNFTokenCreateOffer struct {
 	Owner       string
 	Expiration  int64
 	Destination string
-	Flags       int64
 }
 
 type NFTokenCreateOfferFlags struct {
@@ -110,7 +107,6 @@ type TransactionNFTokenMint struct {
 	Issuer       string
 	TransferFee  int64
 	URI          string
-	Flags        int64
 }
 
 type NFTokenMintFlags struct {
@@ -150,7 +146,6 @@ type AccountSetFlags struct {
 // TransactionType: 'AccountSet'
 type TransactionAccountSet struct {
 	BaseTransaction
-	Flags         int64
 	ClearFlag     int64
 	Domain        string
 	EmailHash     string
@@ -262,7 +257,6 @@ type TransactionOfferCancel struct {
 // TransactionType: 'OfferCreate'
 type TransactionOfferCreate struct {
 	BaseTransaction
-	Flags         int64
 	Expiration    int64
 	OfferSequence int64
 	TakerGets     Amount
@@ -283,7 +277,6 @@ type OfferCreateFlags struct {
 // TransactionType: 'PaymentChannelClaim'
 type TransactionPaymentChannelClaim struct {
 	BaseTransaction
-	Flags     int64
 	Channel   string
 	Balance   string
 	Amount    string
@@ -361,7 +354,6 @@ type TransactionTrustSet struct {
 	LimitAmount IssuedCurrencyAmount
 	QualityIn   int64
 	QualityOut  int64
-	Flags       int64
 }
 
 type TrustSetFlags struct {
@@ -373,7 +365,30 @@ type TrustSetFlags struct {
 	TfClearFreeze   bool `json:"tfClearFreeze,omitempty"`
 }
 
+// Transaction holds the fields of any transaction type. Fields common to all
+// transactions are available through BaseTransaction, and the fields of the
+// individual transaction types through the embedded Transaction* structs.
+// Fields used by more than one transaction type are declared here directly,
+// so that they are decoded regardless of the transaction type.
 type Transaction struct {
+	BaseTransaction
+	ResponseOnlyTxInfo
+	Amount         Amount
+	CancelAfter    int64
+	Channel        string
+	CheckID        string
+	Condition      string
+	DeliverMin     Amount
+	DeliverMax     Amount // api_version 2 name of a Payment's Amount
+	Destination    string
+	DestinationTag int64
+	Expiration     int64
+	InvoiceID      string
+	NFTokenID      string
+	OfferSequence  int64
+	Owner          string
+	PublicKey      string
+	SendMax        Amount
 	TransactionAccountDelete
 	TransactionAccountSet
 	TransactionCheckCancel
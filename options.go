@@ -0,0 +1,155 @@
+package xrpl
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+)
+
+// Option configures a Client created with Dial. Options are applied to a
+// ClientConfig in order, so any setting without a dedicated option can be
+// changed with a custom Option:
+//
+//	xrpl.Dial(ctx, url, func(config *xrpl.ClientConfig) {
+//		config.ResendOnReconnect = true
+//	})
+type Option func(config *ClientConfig)
+
+// Dial creates a client and connects it to url. Unlike NewClient, Dial
+// returns an error instead of panicking if the options are invalid, and
+// returns the dial error if the connection cannot be established before ctx
+// is done. With WithLazyConnect, Dial returns without connecting.
+//
+// Example usage:
+//
+//	client, err := xrpl.Dial(ctx, "wss://s.altnet.rippletest.net:51233",
+//		xrpl.WithReadTimeout(30*time.Second),
+//		xrpl.WithReconnectPolicy(xrpl.ReconnectPolicy{MaxAttempts: 10}),
+//	)
+func Dial(ctx context.Context, url string, opts ...Option) (*Client, error) {
+	config := ClientConfig{URL: url}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	client, err := newClient(config)
+	if err != nil {
+		return nil, err
+	}
+	if config.LazyConnect {
+		return client, nil
+	}
+
+	client.connectOnce.Do(func() { err = client.connect(ctx, false) })
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// WithReadTimeout sets how long the connection may stay silent before it is
+// considered broken.
+func WithReadTimeout(d time.Duration) Option {
+	return func(config *ClientConfig) {
+		config.ReadTimeout = d
+	}
+}
+
+// WithWriteTimeout sets the deadline for writing a message to the connection.
+func WithWriteTimeout(d time.Duration) Option {
+	return func(config *ClientConfig) {
+		config.WriteTimeout = d
+	}
+}
+
+// WithHeartbeat sets the heartbeat interval and mode.
+func WithHeartbeat(interval time.Duration, mode HeartbeatMode) Option {
+	return func(config *ClientConfig) {
+		config.HeartbeatInterval = interval
+		config.HeartbeatMode = mode
+	}
+}
+
+// WithHeartbeatMaxMissed sets the number of consecutive unanswered
+// heartbeats after which the client reconnects.
+func WithHeartbeatMaxMissed(n int) Option {
+	return func(config *ClientConfig) {
+		config.HeartbeatMaxMissed = n
+	}
+}
+
+// WithQueueCapacity sets the capacity of stream channels and of the Errors
+// channel.
+func WithQueueCapacity(n int) Option {
+	return func(config *ClientConfig) {
+		config.QueueCapacity = n
+	}
+}
+
+// WithTLSConfig sets the base TLS configuration.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(config *ClientConfig) {
+		config.TLSConfig = tlsConfig
+	}
+}
+
+// WithClientCertificate sets the PEM encoded client certificate and key, or
+// paths to PEM files, for mutual TLS. passphrase may be empty.
+func WithClientCertificate(certificate, key, passphrase string) Option {
+	return func(config *ClientConfig) {
+		config.Certificate = certificate
+		config.Key = key
+		config.Passphrase = passphrase
+	}
+}
+
+// WithTrustedCertificates sets the CA certificates used to verify the server.
+func WithTrustedCertificates(certificates ...string) Option {
+	return func(config *ClientConfig) {
+		config.TrustedCertificates = certificates
+	}
+}
+
+// WithLogger sets the destination of log output.
+func WithLogger(logger Logger) Option {
+	return func(config *ClientConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithReconnectPolicy sets the backoff policy for re-establishing dropped
+// connections.
+func WithReconnectPolicy(policy ReconnectPolicy) Option {
+	return func(config *ClientConfig) {
+		config.Reconnect = policy
+	}
+}
+
+// WithStateChange sets the callback for connection state transitions.
+func WithStateChange(fn func(ConnectionState)) Option {
+	return func(config *ClientConfig) {
+		config.OnStateChange = fn
+	}
+}
+
+// WithLazyConnect defers connecting until Connect is called or the first
+// request is sent.
+func WithLazyConnect() Option {
+	return func(config *ClientConfig) {
+		config.LazyConnect = true
+	}
+}
+
+// WithInterceptors appends request interceptors.
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(config *ClientConfig) {
+		config.Interceptors = append(config.Interceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptors appends stream message interceptors.
+func WithStreamInterceptors(interceptors ...StreamInterceptor) Option {
+	return func(config *ClientConfig) {
+		config.StreamInterceptors = append(config.StreamInterceptors, interceptors...)
+	}
+}
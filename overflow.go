@@ -0,0 +1,193 @@
+package xrpl
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy decides what happens to a stream message when the consumer
+// of its channel is not keeping up and the channel is full.
+type OverflowPolicy string
+
+const (
+	// Wait until the consumer makes room. A stalled consumer stalls the
+	// read loop, and with it all streams and request responses.
+	OverflowBlock OverflowPolicy = "block"
+
+	// Discard the incoming message.
+	OverflowDropNewest OverflowPolicy = "drop_newest"
+
+	// Discard the oldest queued message to make room for the incoming one.
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+
+	// Queue messages in an unbounded buffer and feed them to the channel in
+	// order as the consumer catches up. Memory use grows with the backlog.
+	OverflowSpill OverflowPolicy = "spill"
+)
+
+func (p OverflowPolicy) valid() bool {
+	switch p {
+	case OverflowBlock, OverflowDropNewest, OverflowDropOldest, OverflowSpill:
+		return true
+	}
+	return false
+}
+
+// streamQueue delivers messages to a stream channel according to an overflow
+// policy and counts messages it had to drop.
+type streamQueue struct {
+	ch      chan []byte
+	policy  OverflowPolicy
+	done    <-chan struct{}
+	dropped atomic.Uint64
+	mutex   sync.Mutex
+	spill   [][]byte
+	notify  chan struct{}
+}
+
+func newStreamQueue(ch chan []byte, policy OverflowPolicy, done <-chan struct{}) *streamQueue {
+	q := &streamQueue{
+		ch:     ch,
+		policy: policy,
+		done:   done,
+	}
+	if policy == OverflowSpill {
+		q.notify = make(chan struct{}, 1)
+	}
+	return q
+}
+
+func (q *streamQueue) push(message []byte) {
+	switch q.policy {
+	case OverflowDropNewest:
+		select {
+		case q.ch <- message:
+		default:
+			q.dropped.Add(1)
+		}
+
+	case OverflowDropOldest:
+		for {
+			select {
+			case q.ch <- message:
+				return
+			default:
+			}
+			select {
+			case <-q.ch:
+				q.dropped.Add(1)
+			default:
+			}
+		}
+
+	case OverflowSpill:
+		q.mutex.Lock()
+		defer q.mutex.Unlock()
+		if len(q.spill) == 0 {
+			select {
+			case q.ch <- message:
+				return
+			default:
+			}
+		}
+		q.spill = append(q.spill, message)
+		select {
+		case q.notify <- struct{}{}:
+		default:
+		}
+
+	default:
+		select {
+		case q.ch <- message:
+		case <-q.done:
+			q.dropped.Add(1)
+		}
+	}
+}
+
+// pump moves spilled messages to the channel in order.
+func (q *streamQueue) pump() {
+	for {
+		q.mutex.Lock()
+		if len(q.spill) == 0 {
+			q.mutex.Unlock()
+			select {
+			case <-q.notify:
+				continue
+			case <-q.done:
+				return
+			}
+		}
+		message := q.spill[0]
+		q.mutex.Unlock()
+
+		select {
+		case q.ch <- message:
+		case <-q.done:
+			return
+		}
+
+		q.mutex.Lock()
+		q.spill[0] = nil
+		q.spill = q.spill[1:]
+		q.mutex.Unlock()
+	}
+}
+
+// backlog returns the number of spilled messages waiting for the channel.
+func (q *streamQueue) backlog() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return len(q.spill)
+}
+
+// newStreamQueues sets up the overflow handling of every stream channel.
+func (c *Client) newStreamQueues() {
+	channels := map[string]chan []byte{
+		StreamTypeLedger:      c.StreamLedger,
+		StreamTypeTransaction: c.StreamTransaction,
+		StreamTypeValidations: c.StreamValidation,
+		StreamTypeManifests:   c.StreamManifest,
+		StreamTypePeerStatus:  c.StreamPeerStatus,
+		StreamTypeConsensus:   c.StreamConsensus,
+		StreamTypePathFind:    c.StreamPathFind,
+		StreamTypeServer:      c.StreamServer,
+		StreamTypeBookChanges: c.StreamBookChanges,
+		streamDefault:         c.StreamDefault,
+	}
+	c.streams = make(map[string]*streamQueue, len(channels))
+	for streamType, ch := range channels {
+		policy, ok := c.config.StreamOverflowPolicies[streamType]
+		if !ok {
+			policy = c.config.StreamOverflowPolicy
+		}
+		q := newStreamQueue(ch, policy, c.shutdown)
+		if policy == OverflowSpill {
+			c.spawn(q.pump)
+		}
+		c.streams[streamType] = q
+	}
+}
+
+// DroppedMessages returns the number of stream messages discarded so far
+// because of overflow, keyed by stream type. Messages for StreamDefault are
+// counted under "default".
+func (c *Client) DroppedMessages() map[string]uint64 {
+	dropped := make(map[string]uint64, len(c.streams))
+	for streamType, q := range c.streams {
+		dropped[streamType] = q.dropped.Load()
+	}
+	return dropped
+}
+
+// SpilledMessages returns the number of messages held in OverflowSpill
+// buffers, keyed by stream type.
+func (c *Client) SpilledMessages() map[string]int {
+	spilled := make(map[string]int, len(c.streams))
+	for streamType, q := range c.streams {
+		if q.policy == OverflowSpill {
+			spilled[streamType] = q.backlog()
+		}
+	}
+	return spilled
+}
@@ -0,0 +1,130 @@
+package xrpl_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/xrpscan/xrpl-go"
+	"github.com/xrpscan/xrpl-go/models"
+	"github.com/xrpscan/xrpl-go/xrpltest"
+)
+
+func withOverflowPolicy(policy xrpl.OverflowPolicy) xrpl.Option {
+	return func(config *xrpl.ClientConfig) {
+		config.StreamOverflowPolicy = policy
+	}
+}
+
+// readLedgers reads n ledger indexes from the client's ledger stream.
+func readLedgers(t *testing.T, client *xrpl.Client, n int) []uint64 {
+	t.Helper()
+	var indexes []uint64
+	for i := 0; i < n; i++ {
+		select {
+		case message := <-client.StreamLedger:
+			var ledger models.LedgerStream
+			if err := json.Unmarshal(message, &ledger); err != nil {
+				t.Fatal(err)
+			}
+			indexes = append(indexes, ledger.LedgerIndex)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out after %d ledgers", len(indexes))
+		}
+	}
+	return indexes
+}
+
+func TestOverflowPolicies(t *testing.T) {
+	tests := []struct {
+		policy  xrpl.OverflowPolicy
+		want    []uint64
+		dropped uint64
+		spilled int
+	}{
+		{xrpl.OverflowDropNewest, []uint64{1, 2}, 3, 0},
+		{xrpl.OverflowDropOldest, []uint64{4, 5}, 3, 0},
+		{xrpl.OverflowSpill, []uint64{1, 2, 3, 4, 5}, 0, 3},
+	}
+	for _, test := range tests {
+		t.Run(string(test.policy), func(t *testing.T) {
+			srv := xrpltest.NewServer()
+			defer srv.Close()
+			client, err := xrpl.Dial(context.Background(), srv.URL, xrpl.WithQueueCapacity(2), withOverflowPolicy(test.policy))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			for index := uint64(1); index <= 5; index++ {
+				srv.EmitLedgerClosed(models.LedgerStream{LedgerIndex: index})
+			}
+			// Messages are handled in order, so all ledgers have been
+			// queued once the response to a later request is in.
+			if _, err := client.Request(xrpl.BaseRequest{"command": "ping"}); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := client.DroppedMessages()[xrpl.StreamTypeLedger]; got != test.dropped {
+				t.Errorf("dropped %d messages, want %d", got, test.dropped)
+			}
+			if test.policy == xrpl.OverflowSpill {
+				// The pump may already hold one spilled message
+				if got := client.SpilledMessages()[xrpl.StreamTypeLedger]; got < test.spilled-1 || got > test.spilled {
+					t.Errorf("spilled %d messages, want %d", got, test.spilled)
+				}
+			}
+
+			got := readLedgers(t, client, len(test.want))
+			if fmt.Sprint(got) != fmt.Sprint(test.want) {
+				t.Errorf("delivered %v, want %v", got, test.want)
+			}
+			select {
+			case message := <-client.StreamLedger:
+				t.Errorf("unexpected message %s", message)
+			case <-time.After(20 * time.Millisecond):
+			}
+			if spilled := client.SpilledMessages()[xrpl.StreamTypeLedger]; spilled != 0 {
+				t.Errorf("%d messages left in spill buffer", spilled)
+			}
+		})
+	}
+}
+
+func TestOverflowBlock(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+	client, err := xrpl.Dial(context.Background(), srv.URL, xrpl.WithQueueCapacity(2), withOverflowPolicy(xrpl.OverflowBlock))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for index := uint64(1); index <= 5; index++ {
+		srv.EmitLedgerClosed(models.LedgerStream{LedgerIndex: index})
+	}
+	got := readLedgers(t, client, 5)
+	if fmt.Sprint(got) != fmt.Sprint([]uint64{1, 2, 3, 4, 5}) {
+		t.Errorf("delivered %v", got)
+	}
+	if dropped := client.DroppedMessages()[xrpl.StreamTypeLedger]; dropped != 0 {
+		t.Errorf("dropped %d messages", dropped)
+	}
+
+	// A consumer that stopped reading holds up the read loop, but not Close
+	for index := uint64(6); index <= 10; index++ {
+		srv.EmitLedgerClosed(models.LedgerStream{LedgerIndex: index})
+	}
+	waitFor(t, "full ledger stream", func() bool { return len(client.StreamLedger) == 2 })
+	closed := make(chan error, 1)
+	go func() { closed <- client.Close() }()
+	select {
+	case err := <-closed:
+		if err != nil {
+			t.Errorf("Close: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close blocked by a stalled stream consumer")
+	}
+}
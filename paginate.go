@@ -0,0 +1,257 @@
+package xrpl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/xrpscan/xrpl-go/methods"
+	"github.com/xrpscan/xrpl-go/models"
+)
+
+// PageOptions control how a Pager walks the pages of a marker-paginated
+// method such as account_lines, account_tx or ledger_data.
+type PageOptions struct {
+	// Page size, sent as "limit". Default: the request's limit, if any
+	Limit uint32
+
+	// Ledger range, sent as ledger_index_min and ledger_index_max for
+	// methods that search a range of ledgers, like account_tx. Zero values
+	// are not sent.
+	LedgerIndexMin int64
+	LedgerIndexMax int64
+
+	// Marker to resume from, as returned by Pager.Marker or Iterator.Marker
+	Marker interface{}
+
+	// Maximum number of pages to fetch. Default: 0 (unlimited)
+	MaxPages int
+}
+
+// Pager fetches consecutive pages of a marker-paginated method, following the
+// marker returned with each page until there is none.
+//
+// Example usage:
+//
+//	pager := xrpl.NewPager(ctx, client, methods.AccountLinesRequest{Account: account}, xrpl.PageOptions{Limit: 400})
+//	for pager.Next() {
+//		fmt.Println(pager.Result()["lines"])
+//	}
+//	if err := pager.Err(); err != nil {
+//		// handle error
+//	}
+type Pager struct {
+	ctx     context.Context
+	t       Transport
+	req     TypedRequest
+	opts    PageOptions
+	request BaseRequest
+	marker  interface{}
+	result  map[string]interface{}
+	pages   int
+	done    bool
+	err     error
+}
+
+// NewPager returns a Pager for req, which may be a typed request from the
+// methods package or a BaseRequest. No request is sent before the first call
+// to Next.
+func NewPager(ctx context.Context, t Transport, req TypedRequest, opts PageOptions) *Pager {
+	return &Pager{
+		ctx:    ctx,
+		t:      t,
+		req:    req,
+		opts:   opts,
+		marker: opts.Marker,
+	}
+}
+
+// Next fetches the next page and reports whether there is one. It returns
+// false when the last page has been fetched, MaxPages is reached, or an error
+// occurred.
+func (p *Pager) Next() bool {
+	if p.done || p.err != nil {
+		return false
+	}
+	if p.opts.MaxPages > 0 && p.pages >= p.opts.MaxPages {
+		p.done = true
+		return false
+	}
+	if p.request == nil {
+		request, err := toBaseRequest(p.req)
+		if err != nil {
+			p.err = err
+			return false
+		}
+		if p.opts.Limit > 0 {
+			request["limit"] = p.opts.Limit
+		}
+		if p.opts.LedgerIndexMin != 0 {
+			request["ledger_index_min"] = p.opts.LedgerIndexMin
+		}
+		if p.opts.LedgerIndexMax != 0 {
+			request["ledger_index_max"] = p.opts.LedgerIndexMax
+		}
+		p.request = request
+	}
+
+	request := make(BaseRequest, len(p.request)+1)
+	for k, v := range p.request {
+		request[k] = v
+	}
+	if p.marker != nil {
+		request["marker"] = p.marker
+	} else {
+		delete(request, "marker")
+	}
+
+	res, err := p.t.RequestContext(p.ctx, request)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	result, ok := res["result"].(map[string]interface{})
+	if !ok {
+		p.err = fmt.Errorf("%w: %s response has no result", ErrMalformedMessage, p.req.Method())
+		return false
+	}
+	p.result = result
+	p.pages++
+	p.marker = result["marker"]
+	if p.marker == nil {
+		p.done = true
+	}
+	return true
+}
+
+// Result returns the result of the current page.
+func (p *Pager) Result() map[string]interface{} {
+	return p.result
+}
+
+// Marker returns the marker returned with the current page, which continues
+// after it, or nil after the last page.
+func (p *Pager) Marker() interface{} {
+	return p.marker
+}
+
+// Pages returns the number of pages fetched so far.
+func (p *Pager) Pages() int {
+	return p.pages
+}
+
+// Err returns the error that stopped the pager, if any.
+func (p *Pager) Err() error {
+	return p.err
+}
+
+// Iterator walks the items of every page of a marker-paginated method, e.g.
+// the trust lines returned by account_lines, decoding each into T.
+//
+// Example usage:
+//
+//	lines := xrpl.AccountLines(ctx, client, methods.AccountLinesRequest{Account: account}, xrpl.PageOptions{})
+//	for lines.Next() {
+//		fmt.Println(lines.Item().Currency, lines.Item().Balance)
+//	}
+//	if err := lines.Err(); err != nil {
+//		// handle error
+//	}
+type Iterator[T any] struct {
+	pager  *Pager
+	field  string
+	marker interface{}
+	items  []interface{}
+	item   T
+	err    error
+}
+
+// Iterate returns an Iterator over the items found under field in each
+// page's result, e.g. "lines" for account_lines.
+func Iterate[T any](ctx context.Context, t Transport, req TypedRequest, field string, opts PageOptions) *Iterator[T] {
+	return &Iterator[T]{
+		pager:  NewPager(ctx, t, req, opts),
+		field:  field,
+		marker: opts.Marker,
+	}
+}
+
+// Next advances to the next item, fetching pages as needed, and reports
+// whether there is one.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for len(it.items) == 0 {
+		marker := it.pager.Marker()
+		if !it.pager.Next() {
+			return false
+		}
+		it.marker = marker
+		items, _ := it.pager.Result()[it.field].([]interface{})
+		it.items = items
+	}
+
+	var item T
+	data, err := json.Marshal(it.items[0])
+	if err == nil {
+		err = json.Unmarshal(data, &item)
+	}
+	if err != nil {
+		it.err = fmt.Errorf("%w: cannot decode %s item: %w", ErrMalformedMessage, it.field, err)
+		return false
+	}
+	it.items = it.items[1:]
+	it.item = item
+	return true
+}
+
+// Item returns the current item.
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Marker returns the marker of the page holding the current item. Resuming
+// from it fetches that page again, so items of the current page may be
+// delivered twice, but none are skipped.
+func (it *Iterator[T]) Marker() interface{} {
+	return it.marker
+}
+
+// Err returns the error that stopped the iterator, if any.
+func (it *Iterator[T]) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.pager.Err()
+}
+
+// AccountLines iterates over the trust lines of an account.
+func AccountLines(ctx context.Context, t Transport, req methods.AccountLinesRequest, opts PageOptions) *Iterator[methods.TrustLine] {
+	return Iterate[methods.TrustLine](ctx, t, req, "lines", opts)
+}
+
+// AccountOffers iterates over the offers of an account.
+func AccountOffers(ctx context.Context, t Transport, req methods.AccountOffersRequest, opts PageOptions) *Iterator[methods.AccountOffer] {
+	return Iterate[methods.AccountOffer](ctx, t, req, "offers", opts)
+}
+
+// AccountObjects iterates over the ledger entries owned by an account.
+func AccountObjects(ctx context.Context, t Transport, req methods.AccountObjectsRequest, opts PageOptions) *Iterator[models.LedgerObject] {
+	return Iterate[models.LedgerObject](ctx, t, req, "account_objects", opts)
+}
+
+// AccountChannels iterates over the payment channels of an account.
+func AccountChannels(ctx context.Context, t Transport, req methods.AccountChannelsRequest, opts PageOptions) *Iterator[methods.Channel] {
+	return Iterate[methods.Channel](ctx, t, req, "channels", opts)
+}
+
+// AccountNFTs iterates over the NFTs of an account.
+func AccountNFTs(ctx context.Context, t Transport, req methods.AccountNFTsRequest, opts PageOptions) *Iterator[methods.AccountNFT] {
+	return Iterate[methods.AccountNFT](ctx, t, req, "account_nfts", opts)
+}
+
+// AccountTx iterates over the transactions of an account.
+func AccountTx(ctx context.Context, t Transport, req methods.AccountTxRequest, opts PageOptions) *Iterator[methods.AccountTransaction] {
+	return Iterate[methods.AccountTransaction](ctx, t, req, "transactions", opts)
+}
@@ -0,0 +1,140 @@
+package xrpl_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/xrpscan/xrpl-go"
+	"github.com/xrpscan/xrpl-go/methods"
+	"github.com/xrpscan/xrpl-go/xrpltest"
+)
+
+// accountLinesServer serves trust lines in three pages, the second of which
+// is empty, as rippled may return when a page's objects hold no lines.
+func accountLinesServer() *xrpltest.Server {
+	pages := map[string]struct {
+		currencies []string
+		next       string
+	}{
+		"":   {[]string{"USD", "EUR"}, "m1"},
+		"m1": {nil, "m2"},
+		"m2": {[]string{"BTC", "ETH"}, ""},
+	}
+	srv := xrpltest.NewServer()
+	srv.Handle("account_lines", func(req xrpl.BaseRequest) (interface{}, error) {
+		marker, _ := req["marker"].(string)
+		page, ok := pages[marker]
+		if !ok {
+			return nil, fmt.Errorf("unknown marker %v", req["marker"])
+		}
+		lines := []interface{}{}
+		for _, currency := range page.currencies {
+			lines = append(lines, map[string]interface{}{"currency": currency, "balance": "1"})
+		}
+		result := map[string]interface{}{"account": req["account"], "lines": lines}
+		if page.next != "" {
+			result["marker"] = page.next
+		}
+		return result, nil
+	})
+	return srv
+}
+
+func accountLinesMarkers(srv *xrpltest.Server) []interface{} {
+	var markers []interface{}
+	for _, req := range srv.Requests() {
+		if req["command"] == "account_lines" {
+			markers = append(markers, req["marker"])
+		}
+	}
+	return markers
+}
+
+func currencies(t *testing.T, lines *xrpl.Iterator[methods.TrustLine], n int) []string {
+	t.Helper()
+	var got []string
+	for (n < 0 || len(got) < n) && lines.Next() {
+		got = append(got, lines.Item().Currency)
+	}
+	if err := lines.Err(); err != nil {
+		t.Fatalf("Err: %v", err)
+	}
+	return got
+}
+
+func TestIteratorFollowsMarkers(t *testing.T) {
+	srv := accountLinesServer()
+	defer srv.Close()
+	client, err := xrpl.Dial(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	lines := xrpl.AccountLines(context.Background(), client, methods.AccountLinesRequest{Account: "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn"}, xrpl.PageOptions{Limit: 2})
+	if got := fmt.Sprint(currencies(t, lines, -1)); got != "[USD EUR BTC ETH]" {
+		t.Errorf("currencies = %s", got)
+	}
+	if got := fmt.Sprint(accountLinesMarkers(srv)); got != "[<nil> m1 m2]" {
+		t.Errorf("markers = %s", got)
+	}
+	requests := srv.Requests()
+	if limit := requests[len(requests)-1]["limit"]; limit != float64(2) {
+		t.Errorf("limit = %v", limit)
+	}
+}
+
+func TestIteratorMaxPages(t *testing.T) {
+	srv := accountLinesServer()
+	defer srv.Close()
+	client, err := xrpl.Dial(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	lines := xrpl.AccountLines(context.Background(), client, methods.AccountLinesRequest{Account: "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn"}, xrpl.PageOptions{MaxPages: 1})
+	if got := fmt.Sprint(currencies(t, lines, -1)); got != "[USD EUR]" {
+		t.Errorf("currencies = %s", got)
+	}
+	if n := len(accountLinesMarkers(srv)); n != 1 {
+		t.Errorf("%d pages fetched, want 1", n)
+	}
+}
+
+func TestIteratorResume(t *testing.T) {
+	srv := accountLinesServer()
+	defer srv.Close()
+	client, err := xrpl.Dial(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	req := methods.AccountLinesRequest{Account: "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn"}
+
+	lines := xrpl.AccountLines(context.Background(), client, req, xrpl.PageOptions{})
+	if got := fmt.Sprint(currencies(t, lines, 1)); got != "[USD]" {
+		t.Fatalf("currencies = %s", got)
+	}
+	if marker := lines.Marker(); marker != nil {
+		t.Errorf("first page marker = %v, want nil", marker)
+	}
+	// Stop in the last page, after the empty one
+	if got := fmt.Sprint(currencies(t, lines, 2)); got != "[EUR BTC]" {
+		t.Fatalf("currencies = %s", got)
+	}
+	marker := lines.Marker()
+	if marker != "m2" {
+		t.Fatalf("Marker() = %v, want m2", marker)
+	}
+
+	// Resuming fetches the page of the current item again
+	resumed := xrpl.AccountLines(context.Background(), client, req, xrpl.PageOptions{Marker: marker})
+	if got := fmt.Sprint(currencies(t, resumed, -1)); got != "[BTC ETH]" {
+		t.Errorf("resumed currencies = %s", got)
+	}
+	if got := fmt.Sprint(accountLinesMarkers(srv)); got != "[<nil> m1 m2 m2]" {
+		t.Errorf("markers = %s", got)
+	}
+}
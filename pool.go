@@ -0,0 +1,539 @@
+package xrpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PoolConfig configures a ClientPool.
+type PoolConfig struct {
+	// Websocket URLs of the rippled or Clio nodes in the pool
+	URLs []string
+
+	// Template for each node's client. URL and OnStateChange are set by the
+	// pool.
+	Client ClientConfig
+
+	// Interval between server_info health checks. Default: 10s
+	HealthCheckInterval time.Duration
+
+	// Timeout of a single health check request. Default: 5s
+	HealthCheckTimeout time.Duration
+
+	// Nodes whose last validated ledger is older than this are considered
+	// unhealthy. Default: 30s
+	MaxLedgerAge time.Duration
+
+	// Called with the URL of the new active node after a failover.
+	OnActiveChange func(url string)
+}
+
+// NodeHealth is the result of the latest health check of a pool node.
+type NodeHealth struct {
+	URL                string
+	State              ConnectionState
+	RTT                time.Duration // Average heartbeat round trip, or the health check's before the first heartbeat
+	ServerState        string        // server_state reported by server_info, e.g. "full"
+	ValidatedLedgerAge time.Duration
+	CheckedAt          time.Time
+	Err                error
+}
+
+// Healthy reports whether the node is connected, in sync with the network and
+// has recently validated a ledger.
+func (h NodeHealth) Healthy(maxLedgerAge time.Duration) bool {
+	if h.State != StateOpen || h.Err != nil {
+		return false
+	}
+	switch h.ServerState {
+	case "full", "validating", "proposing":
+	default:
+		return false
+	}
+	return h.ValidatedLedgerAge <= maxLedgerAge
+}
+
+type poolNode struct {
+	client *Client
+	health NodeHealth
+}
+
+// ClientPool maintains connections to several XRPL nodes and routes requests
+// to the healthiest one. Stream subscriptions are held on the active node only
+// and move with it on failover. Stream messages of the active node are
+// delivered on the pool's Stream* channels.
+type ClientPool struct {
+	config            PoolConfig
+	nodes             []*poolNode
+	active            *poolNode
+	mutex             sync.Mutex
+	failoverMutex     sync.Mutex
+	done              chan struct{}
+	closeOnce         sync.Once
+	StreamLedger      chan []byte
+	StreamTransaction chan []byte
+	StreamValidation  chan []byte
+	StreamManifest    chan []byte
+	StreamPeerStatus  chan []byte
+	StreamConsensus   chan []byte
+	StreamPathFind    chan []byte
+	StreamServer      chan []byte
+	StreamBookChanges chan []byte
+	StreamDefault     chan []byte
+	subscriptions     subscriptions
+}
+
+func (config *PoolConfig) Validate() error {
+	if len(config.URLs) == 0 {
+		return errors.New("cannot create a client pool without URLs")
+	}
+	for _, url := range config.URLs {
+		if len(url) == 0 {
+			return errors.New("cannot create a client pool with an empty URL")
+		}
+	}
+	if config.HealthCheckInterval < 0 {
+		return fmt.Errorf("pool health check interval out of bounds: %s", config.HealthCheckInterval)
+	}
+	if config.HealthCheckTimeout < 0 {
+		return fmt.Errorf("pool health check timeout out of bounds: %s", config.HealthCheckTimeout)
+	}
+	if config.MaxLedgerAge < 0 {
+		return fmt.Errorf("pool max ledger age out of bounds: %s", config.MaxLedgerAge)
+	}
+	return nil
+}
+
+// NewClientPool connects to every node in config.URLs, checks their health
+// and selects the healthiest one as the active node. Like NewClient, it
+// panics if the configuration is invalid. Use DialPool to get configuration
+// and connection errors returned instead.
+func NewClientPool(config PoolConfig) *ClientPool {
+	p, err := newClientPool(config)
+	if err != nil {
+		panic(err)
+	}
+	p.start(context.Background())
+	return p
+}
+
+// DialPool creates a pool like NewClientPool, but returns an error if the
+// configuration is invalid or none of the nodes can be connected before ctx
+// is done. Nodes that fail to connect keep retrying in the background.
+func DialPool(ctx context.Context, config PoolConfig) (*ClientPool, error) {
+	p, err := newClientPool(config)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.start(ctx); err != nil {
+		p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+func newClientPool(config PoolConfig) (*ClientPool, error) {
+	if config.HealthCheckInterval == 0 {
+		config.HealthCheckInterval = 10 * time.Second
+	}
+	if config.HealthCheckTimeout == 0 {
+		config.HealthCheckTimeout = 5 * time.Second
+	}
+	if config.MaxLedgerAge == 0 {
+		config.MaxLedgerAge = 30 * time.Second
+	}
+	if config.Client.QueueCapacity == 0 {
+		config.Client.QueueCapacity = 128
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	capacity := config.Client.QueueCapacity
+	p := &ClientPool{
+		config:            config,
+		done:              make(chan struct{}),
+		StreamLedger:      make(chan []byte, capacity),
+		StreamTransaction: make(chan []byte, capacity),
+		StreamValidation:  make(chan []byte, capacity),
+		StreamManifest:    make(chan []byte, capacity),
+		StreamPeerStatus:  make(chan []byte, capacity),
+		StreamConsensus:   make(chan []byte, capacity),
+		StreamPathFind:    make(chan []byte, capacity),
+		StreamServer:      make(chan []byte, capacity),
+		StreamBookChanges: make(chan []byte, capacity),
+		StreamDefault:     make(chan []byte, capacity),
+		subscriptions:     newSubscriptions(make(map[string]bool)),
+	}
+
+	for _, url := range config.URLs {
+		node := &poolNode{health: NodeHealth{URL: url}}
+		clientConfig := config.Client
+		clientConfig.URL = url
+		clientConfig.OnStateChange = func(state ConnectionState) {
+			p.handleStateChange(node, state)
+		}
+		client, err := newClient(clientConfig)
+		if err != nil {
+			for _, node := range p.nodes {
+				node.client.Close()
+			}
+			return nil, fmt.Errorf("invalid client config for %s: %w", url, err)
+		}
+		node.client = client
+		p.nodes = append(p.nodes, node)
+	}
+	return p, nil
+}
+
+// start connects the nodes concurrently, selects the active node and starts
+// health checks. It returns an error only if no node could be connected.
+func (p *ClientPool) start(ctx context.Context) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(p.nodes))
+	if !p.config.Client.LazyConnect {
+		for i, node := range p.nodes {
+			wg.Add(1)
+			go func(i int, node *poolNode) {
+				defer wg.Done()
+				errs[i] = node.client.Connect(ctx)
+			}(i, node)
+		}
+		wg.Wait()
+	}
+	for _, node := range p.nodes {
+		go p.forward(node)
+	}
+
+	p.checkHealth()
+	p.failover()
+	go p.monitor()
+
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot connect to any pool node: %w", errors.Join(errs...))
+}
+
+// Active returns the client of the node that currently serves requests and
+// stream subscriptions.
+func (p *ClientPool) Active() *Client {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.active == nil {
+		return nil
+	}
+	return p.active.client
+}
+
+// Health returns the latest health check result of every node in the pool.
+func (p *ClientPool) Health() []NodeHealth {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	health := make([]NodeHealth, 0, len(p.nodes))
+	for _, node := range p.nodes {
+		health = append(health, node.health)
+	}
+	return health
+}
+
+func (p *ClientPool) Request(req BaseRequest) (BaseResponse, error) {
+	return p.RequestContext(context.Background(), req)
+}
+
+// RequestContext sends req to the active node. If the node is not connected,
+// or the connection drops before an idempotent request is answered, the
+// request is retried on the next healthiest node.
+func (p *ClientPool) RequestContext(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+	command, _ := req["command"].(string)
+	var err error
+	for _, node := range p.candidates() {
+		var res BaseResponse
+		res, err = node.client.RequestContext(ctx, req)
+		if errors.Is(err, ErrNotConnected) ||
+			(errors.Is(err, ErrConnectionLost) && idempotentCommands[command]) {
+			go p.failover()
+			continue
+		}
+		return res, err
+	}
+	return nil, err
+}
+
+// Subscribe to streams on the active node. The subscription is moved to the
+// new active node on failover.
+func (p *ClientPool) Subscribe(streams []string) (BaseResponse, error) {
+	return p.SubscribeTo(context.Background(), Subscription{Streams: streams})
+}
+
+func (p *ClientPool) Unsubscribe(streams []string) (BaseResponse, error) {
+	return p.UnsubscribeFrom(context.Background(), Subscription{Streams: streams})
+}
+
+// SubscribeTo subscribes to streams, accounts and order books on the active
+// node. The subscriptions are moved to the new active node on failover.
+func (p *ClientPool) SubscribeTo(ctx context.Context, sub Subscription) (BaseResponse, error) {
+	client := p.Active()
+	if client == nil {
+		return nil, ErrNotConnected
+	}
+	res, err := client.SubscribeTo(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	p.subscriptions.add(sub)
+	p.mutex.Unlock()
+	return res, nil
+}
+
+func (p *ClientPool) UnsubscribeFrom(ctx context.Context, sub Subscription) (BaseResponse, error) {
+	p.mutex.Lock()
+	p.subscriptions.remove(sub)
+	p.mutex.Unlock()
+
+	client := p.Active()
+	if client == nil {
+		return nil, ErrNotConnected
+	}
+	return client.UnsubscribeFrom(ctx, sub)
+}
+
+// Close every node's connection and stop health checks. Nodes are closed
+// concurrently, each draining its in-flight requests as in Client.Close.
+func (p *ClientPool) Close() error {
+	var err error
+	p.closeOnce.Do(func() {
+		close(p.done)
+		var wg sync.WaitGroup
+		var mutex sync.Mutex
+		for _, node := range p.nodes {
+			wg.Add(1)
+			go func(node *poolNode) {
+				defer wg.Done()
+				if e := node.client.Close(); e != nil {
+					mutex.Lock()
+					err = e
+					mutex.Unlock()
+				}
+			}(node)
+		}
+		wg.Wait()
+	})
+	return err
+}
+
+func (p *ClientPool) monitor() {
+	ticker := time.NewTicker(p.config.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.checkHealth()
+			p.failover()
+		}
+	}
+}
+
+// checkHealth sends server_info to every node concurrently and records the
+// results.
+func (p *ClientPool) checkHealth() {
+	var wg sync.WaitGroup
+	for _, node := range p.nodes {
+		wg.Add(1)
+		go func(node *poolNode) {
+			defer wg.Done()
+			health := p.probe(node)
+			p.mutex.Lock()
+			node.health = health
+			p.mutex.Unlock()
+		}(node)
+	}
+	wg.Wait()
+}
+
+func (p *ClientPool) probe(node *poolNode) NodeHealth {
+	health := NodeHealth{
+		URL:       node.client.config.URL,
+		State:     node.client.State(),
+		CheckedAt: time.Now(),
+	}
+	if health.State != StateOpen {
+		health.Err = ErrNotConnected
+		return health
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.config.HealthCheckTimeout)
+	defer cancel()
+	start := time.Now()
+	res, err := node.client.RequestContext(ctx, BaseRequest{"command": "server_info"})
+	health.RTT = time.Since(start)
+	if rtt := node.client.RTT(); rtt.Samples > 0 {
+		health.RTT = rtt.Average
+	}
+	if err != nil {
+		health.Err = err
+		return health
+	}
+
+	result, _ := res["result"].(map[string]interface{})
+	info, _ := result["info"].(map[string]interface{})
+	health.ServerState, _ = info["server_state"].(string)
+	validated, ok := info["validated_ledger"].(map[string]interface{})
+	if !ok {
+		health.Err = errors.New("node has no validated ledger")
+		return health
+	}
+	age, _ := validated["age"].(float64)
+	health.ValidatedLedgerAge = time.Duration(age * float64(time.Second))
+	return health
+}
+
+// candidates returns the pool's nodes ordered by preference: the active node
+// first, followed by healthy nodes by RTT and then the remaining nodes.
+func (p *ClientPool) candidates() []*poolNode {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	nodes := make([]*poolNode, 0, len(p.nodes))
+	if p.active != nil {
+		nodes = append(nodes, p.active)
+	}
+	for _, healthy := range []bool{true, false} {
+		var group []*poolNode
+		for _, node := range p.nodes {
+			if node != p.active && node.health.Healthy(p.config.MaxLedgerAge) == healthy {
+				group = append(group, node)
+			}
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].health.RTT < group[j].health.RTT
+		})
+		nodes = append(nodes, group...)
+	}
+	return nodes
+}
+
+// failover keeps the active node while it is healthy. Otherwise the
+// healthiest node becomes active and stream subscriptions are moved to it.
+func (p *ClientPool) failover() {
+	p.failoverMutex.Lock()
+	defer p.failoverMutex.Unlock()
+
+	p.mutex.Lock()
+	old := p.active
+	if old != nil && old.health.Healthy(p.config.MaxLedgerAge) && old.client.State() == StateOpen {
+		p.mutex.Unlock()
+		return
+	}
+	var next *poolNode
+	for _, node := range p.nodes {
+		if node.client.State() != StateOpen {
+			continue
+		}
+		if next == nil ||
+			(node.health.Healthy(p.config.MaxLedgerAge) && !next.health.Healthy(p.config.MaxLedgerAge)) ||
+			(node.health.Healthy(p.config.MaxLedgerAge) == next.health.Healthy(p.config.MaxLedgerAge) && node.health.RTT < next.health.RTT) {
+			next = node
+		}
+	}
+	if next == nil || next == old ||
+		(old != nil && old.client.State() == StateOpen && !next.health.Healthy(p.config.MaxLedgerAge)) {
+		// Nothing better to switch to
+		p.mutex.Unlock()
+		return
+	}
+	p.active = next
+	sub := p.subscriptions.list()
+	p.mutex.Unlock()
+
+	if !sub.empty() {
+		if old != nil {
+			// Make sure the old node does not restore these subscriptions
+			// when it reconnects.
+			old.client.mutex.Lock()
+			old.client.subscriptions.remove(sub)
+			old.client.mutex.Unlock()
+			if old.client.State() == StateOpen {
+				go old.client.UnsubscribeFrom(context.Background(), sub)
+			}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), p.config.HealthCheckTimeout)
+		if _, err := next.client.SubscribeTo(ctx, sub); err != nil {
+			next.client.reportError("pool subscription failed", err)
+		}
+		cancel()
+	}
+
+	next.client.log.Info("pool active node changed", "url", next.client.config.URL)
+	if p.config.OnActiveChange != nil {
+		p.config.OnActiveChange(next.client.config.URL)
+	}
+}
+
+func (p *ClientPool) handleStateChange(node *poolNode, state ConnectionState) {
+	p.mutex.Lock()
+	node.health.State = state
+	active := node == p.active
+	p.mutex.Unlock()
+
+	if active && state != StateOpen {
+		go p.failover()
+	}
+}
+
+// forward copies stream messages of a node to the pool's channels while the
+// node is active. Messages from inactive nodes are discarded.
+func (p *ClientPool) forward(node *poolNode) {
+	c := node.client
+	for {
+		var message []byte
+		var stream chan []byte
+		select {
+		case <-p.done:
+			return
+		case message = <-c.StreamLedger:
+			stream = p.StreamLedger
+		case message = <-c.StreamTransaction:
+			stream = p.StreamTransaction
+		case message = <-c.StreamValidation:
+			stream = p.StreamValidation
+		case message = <-c.StreamManifest:
+			stream = p.StreamManifest
+		case message = <-c.StreamPeerStatus:
+			stream = p.StreamPeerStatus
+		case message = <-c.StreamConsensus:
+			stream = p.StreamConsensus
+		case message = <-c.StreamPathFind:
+			stream = p.StreamPathFind
+		case message = <-c.StreamServer:
+			stream = p.StreamServer
+		case message = <-c.StreamBookChanges:
+			stream = p.StreamBookChanges
+		case message = <-c.StreamDefault:
+			stream = p.StreamDefault
+		}
+
+		p.mutex.Lock()
+		active := node == p.active
+		p.mutex.Unlock()
+		if !active {
+			continue
+		}
+		select {
+		case stream <- message:
+		case <-p.done:
+			return
+		}
+	}
+}
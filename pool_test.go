@@ -0,0 +1,118 @@
+package xrpl_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/xrpscan/xrpl-go"
+	"github.com/xrpscan/xrpl-go/xrpltest"
+)
+
+func serverInfo(state string) map[string]interface{} {
+	return map[string]interface{}{
+		"info": map[string]interface{}{
+			"server_state":     state,
+			"validated_ledger": map[string]interface{}{"age": 1},
+		},
+	}
+}
+
+func TestDialPool(t *testing.T) {
+	syncing := xrpltest.NewServer()
+	defer syncing.Close()
+	syncing.On("server_info", serverInfo("syncing"))
+	full := xrpltest.NewServer()
+	defer full.Close()
+	full.On("server_info", serverInfo("full"))
+	full.On("fee", map[string]interface{}{"current_ledger_size": "12"})
+
+	pool, err := xrpl.DialPool(context.Background(), xrpl.PoolConfig{
+		URLs: []string{syncing.URL, full.URL},
+	})
+	if err != nil {
+		t.Fatalf("DialPool: %v", err)
+	}
+	defer pool.Close()
+
+	if pool.Active() == nil {
+		t.Fatal("pool has no active node")
+	}
+	res, err := pool.Request(xrpl.BaseRequest{"command": "fee"})
+	if err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+	result, _ := res["result"].(map[string]interface{})
+	if result["current_ledger_size"] != "12" {
+		t.Errorf("request was not routed to the healthy node: %v", res)
+	}
+}
+
+func TestDialPoolInvalidConfig(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+
+	configs := map[string]xrpl.PoolConfig{
+		"no urls":        {},
+		"empty url":      {URLs: []string{srv.URL, ""}},
+		"invalid client": {URLs: []string{srv.URL}, Client: xrpl.ClientConfig{WriteTimeout: -time.Second}},
+	}
+	for name, config := range configs {
+		if pool, err := xrpl.DialPool(context.Background(), config); err == nil {
+			pool.Close()
+			t.Errorf("%s: DialPool succeeded", name)
+		}
+	}
+}
+
+func TestDialPoolUnreachable(t *testing.T) {
+	srv := xrpltest.NewServer()
+	url := srv.URL
+	srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	pool, err := xrpl.DialPool(ctx, xrpl.PoolConfig{URLs: []string{url}})
+	if err == nil {
+		pool.Close()
+		t.Fatal("DialPool succeeded without a reachable node")
+	}
+}
+
+func TestPoolHealthUsesHeartbeatRTT(t *testing.T) {
+	slow := xrpltest.NewServer()
+	defer slow.Close()
+	slow.On("server_info", serverInfo("full"))
+	slow.SetCommandLatency("ping", 100*time.Millisecond)
+	fast := xrpltest.NewServer()
+	defer fast.Close()
+	fast.On("server_info", serverInfo("full"))
+
+	pool, err := xrpl.DialPool(context.Background(), xrpl.PoolConfig{
+		URLs:                []string{slow.URL, fast.URL},
+		HealthCheckInterval: 20 * time.Millisecond,
+		Client: xrpl.ClientConfig{
+			HeartbeatInterval: 150 * time.Millisecond,
+			HeartbeatMode:     xrpl.HeartbeatCommand,
+			ReadTimeout:       time.Second,
+		},
+	})
+	if err != nil {
+		t.Fatalf("DialPool: %v", err)
+	}
+	defer pool.Close()
+
+	waitFor(t, "heartbeat RTT in health", func() bool {
+		for _, health := range pool.Health() {
+			if health.URL == slow.URL {
+				return health.RTT >= 100*time.Millisecond
+			}
+		}
+		return false
+	})
+	for _, health := range pool.Health() {
+		if health.URL == fast.URL && health.RTT >= 100*time.Millisecond {
+			t.Errorf("fast node RTT = %s", health.RTT)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package xrpl
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// ConnectionState describes the lifecycle state of a Client. A client moves
+// from New through Connecting to Open, may drop to Reconnecting and back to
+// Open any number of times, and ends with Closing and Closed once Close is
+// called or reconnection is abandoned. State transitions are reported to
+// ClientConfig.OnStateChange.
+type ConnectionState string
+
+const (
+	StateNew          ConnectionState = "new"
+	StateConnecting   ConnectionState = "connecting"
+	StateOpen         ConnectionState = "open"
+	StateReconnecting ConnectionState = "reconnecting"
+	StateClosing      ConnectionState = "closing"
+	StateClosed       ConnectionState = "closed"
+)
+
+// Allowed state transitions. Anything else is ignored by setState.
+var stateTransitions = map[ConnectionState][]ConnectionState{
+	StateNew:          {StateConnecting, StateReconnecting, StateClosing},
+	StateConnecting:   {StateOpen, StateReconnecting, StateClosing},
+	StateOpen:         {StateReconnecting, StateClosing},
+	StateReconnecting: {StateOpen, StateClosing},
+	StateClosing:      {StateClosed},
+}
+
+// ReconnectPolicy controls how a Client re-establishes a connection that
+// dropped or could not be established in the first place. Zero values select
+// the defaults noted below.
+type ReconnectPolicy struct {
+	InitialDelay time.Duration // Delay before the first retry. Default: 1s
+	MaxDelay     time.Duration // Upper bound for the delay between retries. Default: 30s
+	Multiplier   float64       // Delay growth factor per attempt. Default: 2
+	Jitter       float64       // Random +/- fraction applied to each delay, 0..1. Default: 0.2
+	MaxAttempts  int           // Dial attempts before giving up. Default: 0 (unlimited)
+}
+
+func (p *ReconnectPolicy) setDefaults() {
+	if p.InitialDelay == 0 {
+		p.InitialDelay = time.Second
+	}
+	if p.MaxDelay == 0 {
+		p.MaxDelay = 30 * time.Second
+	}
+	if p.Multiplier == 0 {
+		p.Multiplier = 2
+	}
+	if p.Jitter == 0 {
+		p.Jitter = 0.2
+	}
+}
+
+func (p *ReconnectPolicy) validate() error {
+	if p.InitialDelay < 0 || p.MaxDelay < p.InitialDelay {
+		return fmt.Errorf("reconnect delay out of bounds: initial %s, max %s", p.InitialDelay, p.MaxDelay)
+	}
+	if p.Multiplier < 1 {
+		return fmt.Errorf("reconnect multiplier must be at least 1: %g", p.Multiplier)
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return fmt.Errorf("reconnect jitter out of bounds: %g", p.Jitter)
+	}
+	if p.MaxAttempts < 0 {
+		return fmt.Errorf("reconnect max attempts out of bounds: %d", p.MaxAttempts)
+	}
+	return nil
+}
+
+// delay returns the backoff to wait after the given failed attempt (starting
+// at 1), including jitter.
+func (p *ReconnectPolicy) delay(attempt int) time.Duration {
+	d := float64(p.InitialDelay) * math.Pow(p.Multiplier, float64(attempt-1))
+	if d > float64(p.MaxDelay) {
+		d = float64(p.MaxDelay)
+	}
+	d += d * p.Jitter * (rand.Float64()*2 - 1)
+	return time.Duration(d)
+}
+
+// Reconnect closes the current websocket connection and dials a new one,
+// retrying with exponential backoff as configured by ClientConfig.Reconnect.
+// Once connected, pending requests are re-sent or failed according to
+// ClientConfig.ResendOnReconnect, and stream subscriptions are restored. If
+// a reconnection is already in progress, Reconnect returns immediately; if
+// that reconnection has already replaced the connection, it starts over once
+// it is done.
+func (c *Client) Reconnect() error {
+	return c.reconnect(0)
+}
+
+// reconnect runs the reconnection loop. failed is the number of dial attempts
+// that have already failed before the loop was started.
+func (c *Client) reconnect(failed int) error {
+	c.mutex.Lock()
+	if c.reconnecting {
+		// A connection that failed before the running reconnection has
+		// finished must be replaced as well. While it is still dialing,
+		// there is nothing to replace yet.
+		if c.connection != nil {
+			c.reconnectAgain = true
+		}
+		c.mutex.Unlock()
+		return nil
+	}
+	c.reconnecting = true
+	c.mutex.Unlock()
+
+	for {
+		err := c.replaceConnection(failed)
+
+		c.mutex.Lock()
+		again := err == nil && c.reconnectAgain
+		c.reconnectAgain = false
+		if !again {
+			c.reconnecting = false
+		}
+		c.mutex.Unlock()
+		if err != nil {
+			return err
+		}
+		if !again {
+			break
+		}
+		failed = 0
+	}
+
+	// Re-subscribe xrpl streams, accounts and order books. If the new
+	// connection drops meanwhile, its reader starts another reconnection.
+	if sub := c.ActiveSubscriptions(); !sub.empty() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.ReadTimeout)
+		defer cancel()
+		if _, err := c.SubscribeTo(ctx, sub); err != nil {
+			c.reportError("re-subscription failed", err)
+		}
+	}
+	return nil
+}
+
+// replaceConnection closes the current connection and dials a new one.
+func (c *Client) replaceConnection(failed int) error {
+	if !c.setState(StateReconnecting) {
+		return ErrClientClosed
+	}
+
+	// Close old websocket connection. Responses to requests sent on it will
+	// never arrive.
+	c.close()
+	c.failPendingRequests(c.config.ResendOnReconnect)
+
+	// Create a new websocket connection
+	if err := c.redial(failed); err != nil {
+		c.failPendingRequests(false)
+		if err != ErrClientClosed {
+			c.reportError("websocket reconnection abandoned", err)
+			// Close waits for this goroutine, so it cannot be called directly
+			go c.Close()
+		}
+		return err
+	}
+	c.resendPendingRequests()
+	return nil
+}
+
+func (c *Client) redial(failed int) error {
+	policy := c.config.Reconnect
+	for attempt := failed + 1; ; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(policy.delay(attempt - 1)):
+			case <-c.shutdown:
+				return ErrClientClosed
+			}
+		}
+
+		_, err := c.NewConnection()
+		if err == nil {
+			return nil
+		}
+		c.reportError("websocket reconnection failed", err, "attempt", attempt)
+		if err == ErrClientClosed {
+			return err
+		}
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			return fmt.Errorf("giving up after %d connection attempts: %w", attempt, err)
+		}
+	}
+}
+
+// State returns the current state of the websocket connection.
+func (c *Client) State() ConnectionState {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.state
+}
+
+// setState moves the client to state if the transition is allowed, and
+// reports whether the client is in state afterwards.
+func (c *Client) setState(state ConnectionState) bool {
+	c.mutex.Lock()
+	if c.state == state {
+		c.mutex.Unlock()
+		return true
+	}
+	allowed := false
+	for _, next := range stateTransitions[c.state] {
+		if next == state {
+			allowed = true
+			break
+		}
+	}
+	if allowed {
+		c.state = state
+	}
+	c.mutex.Unlock()
+
+	if allowed && c.config.OnStateChange != nil {
+		c.config.OnStateChange(state)
+	}
+	return allowed
+}
@@ -0,0 +1,130 @@
+package xrpl
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/xrpscan/xrpl-go/models"
+)
+
+// typedHandlers are the handlers registered for one stream message type and
+// one Go type. Each message is decoded once and passed to all of them.
+type typedHandlers struct {
+	typ    reflect.Type
+	decode func([]byte) (interface{}, error)
+	fns    []func(interface{})
+}
+
+// OnStream registers fn to be called with every message of streamType,
+// decoded into T. streamType is one of the StreamType* constants, or the raw
+// "type" value of a message that has no constant.
+//
+// Once a handler is registered for a stream, its messages are no longer
+// delivered on the corresponding Stream* channel. Handlers run on the
+// client's read loop: they must return quickly and must not wait for the
+// response of a request on the same client.
+//
+// Example usage:
+//
+//	xrpl.OnStream(client, xrpl.StreamTypeLedger, func(ledger models.LedgerStream) {
+//		fmt.Println(ledger.LedgerIndex)
+//	})
+func OnStream[T any](c *Client, streamType string, fn func(T)) {
+	responseType := StreamResponseType(streamType)
+	if responseType == "" {
+		responseType = streamType
+	}
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+
+	c.handlerMutex.Lock()
+	defer c.handlerMutex.Unlock()
+	for _, h := range c.handlers[responseType] {
+		if h.typ == typ {
+			h.fns = append(h.fns, func(v interface{}) { fn(v.(T)) })
+			return
+		}
+	}
+	c.handlers[responseType] = append(c.handlers[responseType], &typedHandlers{
+		typ: typ,
+		decode: func(message []byte) (interface{}, error) {
+			var v T
+			err := json.Unmarshal(message, &v)
+			return v, err
+		},
+		fns: []func(interface{}){func(v interface{}) { fn(v.(T)) }},
+	})
+}
+
+// OnLedger registers a handler for the ledger stream.
+func (c *Client) OnLedger(fn func(models.LedgerStream)) {
+	OnStream(c, StreamTypeLedger, fn)
+}
+
+// OnTransaction registers a handler for the transactions,
+// transactions_proposed, account and order book streams.
+func (c *Client) OnTransaction(fn func(models.TransactionStream)) {
+	OnStream(c, StreamTypeTransaction, fn)
+}
+
+// OnValidation registers a handler for the validations stream.
+func (c *Client) OnValidation(fn func(models.ValidationStream)) {
+	OnStream(c, StreamTypeValidations, fn)
+}
+
+// OnPeerStatus registers a handler for the peer_status stream.
+func (c *Client) OnPeerStatus(fn func(models.PeerStatusStream)) {
+	OnStream(c, StreamTypePeerStatus, fn)
+}
+
+// OnConsensus registers a handler for the consensus stream.
+func (c *Client) OnConsensus(fn func(models.ConsensusStream)) {
+	OnStream(c, StreamTypeConsensus, fn)
+}
+
+// OnPathFind registers a handler for path_find updates.
+func (c *Client) OnPathFind(fn func(models.PathFindStream)) {
+	OnStream(c, StreamTypePathFind, fn)
+}
+
+// OnServerStatus registers a handler for the server stream.
+func (c *Client) OnServerStatus(fn func(models.ServerStatusStream)) {
+	OnStream(c, StreamTypeServer, fn)
+}
+
+// OnManifest registers a handler for the manifests stream.
+func (c *Client) OnManifest(fn func(models.ManifestStream)) {
+	OnStream(c, StreamTypeManifests, fn)
+}
+
+// OnBookChanges registers a handler for the book_changes stream.
+func (c *Client) OnBookChanges(fn func(models.BookChangesStream)) {
+	OnStream(c, StreamTypeBookChanges, fn)
+}
+
+// dispatch passes message to the handlers registered for responseType. It
+// returns false if there are none. Decode errors are reported on the Errors
+// channel.
+func (c *Client) dispatch(responseType string, message []byte) bool {
+	c.handlerMutex.RLock()
+	handlers := c.handlers[responseType]
+	c.handlerMutex.RUnlock()
+	if len(handlers) == 0 {
+		return false
+	}
+
+	for _, h := range handlers {
+		v, err := h.decode(message)
+		if err != nil {
+			c.reportError("cannot decode stream message", fmt.Errorf("%w: %s: %w", ErrMalformedMessage, responseType, err), "type", responseType)
+			continue
+		}
+		c.handlerMutex.RLock()
+		fns := h.fns
+		c.handlerMutex.RUnlock()
+		for _, fn := range fns {
+			fn(v)
+		}
+	}
+	return true
+}
@@ -0,0 +1,200 @@
+package xrpl
+
+import (
+	"context"
+	"fmt"
+)
+
+// BookCurrency identifies one side of an order book. Issuer is omitted for
+// the native asset.
+type BookCurrency struct {
+	Currency string `json:"currency"`
+	Issuer   string `json:"issuer,omitempty"`
+}
+
+// Book is an order book subscription. Set Snapshot to receive the current
+// offers in the subscribe response, and Both to also follow the reverse book.
+type Book struct {
+	TakerGets BookCurrency `json:"taker_gets"`
+	TakerPays BookCurrency `json:"taker_pays"`
+	Taker     string       `json:"taker,omitempty"`
+	Snapshot  bool         `json:"snapshot,omitempty"`
+	Both      bool         `json:"both,omitempty"`
+}
+
+// key identifies the book subscription. Snapshot is left out, as it only
+// affects the subscribe response.
+func (b Book) key() string {
+	return fmt.Sprintf("%s/%s:%s/%s:%s:%t",
+		b.TakerGets.Currency, b.TakerGets.Issuer,
+		b.TakerPays.Currency, b.TakerPays.Issuer,
+		b.Taker, b.Both)
+}
+
+// Subscription is a set of streams, accounts and order books to subscribe to
+// or unsubscribe from in a single request.
+//
+// Reference: https://xrpl.org/subscribe.html
+type Subscription struct {
+	Streams          []string
+	Accounts         []string
+	AccountsProposed []string
+	Books            []Book
+}
+
+func (s Subscription) empty() bool {
+	return len(s.Streams) == 0 &&
+		len(s.Accounts) == 0 &&
+		len(s.AccountsProposed) == 0 &&
+		len(s.Books) == 0
+}
+
+func (s Subscription) request(command string) BaseRequest {
+	req := BaseRequest{"command": command}
+	if len(s.Streams) > 0 {
+		req["streams"] = s.Streams
+	}
+	if len(s.Accounts) > 0 {
+		req["accounts"] = s.Accounts
+	}
+	if len(s.AccountsProposed) > 0 {
+		req["accounts_proposed"] = s.AccountsProposed
+	}
+	if len(s.Books) > 0 {
+		req["books"] = s.Books
+	}
+	return req
+}
+
+// subscriptions tracks what a client is subscribed to, so that it can be
+// restored after reconnecting.
+type subscriptions struct {
+	streams          map[string]bool
+	accounts         map[string]bool
+	accountsProposed map[string]bool
+	books            map[string]Book
+}
+
+func newSubscriptions(streams map[string]bool) subscriptions {
+	return subscriptions{
+		streams:          streams,
+		accounts:         make(map[string]bool),
+		accountsProposed: make(map[string]bool),
+		books:            make(map[string]Book),
+	}
+}
+
+func (s *subscriptions) add(sub Subscription) {
+	for _, stream := range sub.Streams {
+		s.streams[stream] = true
+	}
+	for _, account := range sub.Accounts {
+		s.accounts[account] = true
+	}
+	for _, account := range sub.AccountsProposed {
+		s.accountsProposed[account] = true
+	}
+	for _, book := range sub.Books {
+		s.books[book.key()] = book
+	}
+}
+
+func (s *subscriptions) remove(sub Subscription) {
+	for _, stream := range sub.Streams {
+		delete(s.streams, stream)
+	}
+	for _, account := range sub.Accounts {
+		delete(s.accounts, account)
+	}
+	for _, account := range sub.AccountsProposed {
+		delete(s.accountsProposed, account)
+	}
+	for _, book := range sub.Books {
+		delete(s.books, book.key())
+	}
+}
+
+func (s *subscriptions) list() Subscription {
+	var sub Subscription
+	for stream := range s.streams {
+		sub.Streams = append(sub.Streams, stream)
+	}
+	for account := range s.accounts {
+		sub.Accounts = append(sub.Accounts, account)
+	}
+	for account := range s.accountsProposed {
+		sub.AccountsProposed = append(sub.AccountsProposed, account)
+	}
+	for _, book := range s.books {
+		sub.Books = append(sub.Books, book)
+	}
+	return sub
+}
+
+// SubscribeTo subscribes to all streams, accounts and order books in sub with
+// a single request. Subscriptions are restored after reconnecting.
+func (c *Client) SubscribeTo(ctx context.Context, sub Subscription) (BaseResponse, error) {
+	res, err := c.RequestContext(ctx, sub.request("subscribe"))
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	c.subscriptions.add(sub)
+	c.mutex.Unlock()
+
+	return res, nil
+}
+
+// UnsubscribeFrom cancels the subscriptions in sub with a single request.
+func (c *Client) UnsubscribeFrom(ctx context.Context, sub Subscription) (BaseResponse, error) {
+	res, err := c.RequestContext(ctx, sub.request("unsubscribe"))
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	c.subscriptions.remove(sub)
+	c.mutex.Unlock()
+
+	return res, nil
+}
+
+// SubscribeAccounts subscribes to validated transactions affecting accounts.
+// They are delivered on StreamTransaction.
+func (c *Client) SubscribeAccounts(accounts []string) (BaseResponse, error) {
+	return c.SubscribeTo(context.Background(), Subscription{Accounts: accounts})
+}
+
+func (c *Client) UnsubscribeAccounts(accounts []string) (BaseResponse, error) {
+	return c.UnsubscribeFrom(context.Background(), Subscription{Accounts: accounts})
+}
+
+// SubscribeAccountsProposed subscribes to both proposed and validated
+// transactions affecting accounts. They are delivered on StreamTransaction.
+func (c *Client) SubscribeAccountsProposed(accounts []string) (BaseResponse, error) {
+	return c.SubscribeTo(context.Background(), Subscription{AccountsProposed: accounts})
+}
+
+func (c *Client) UnsubscribeAccountsProposed(accounts []string) (BaseResponse, error) {
+	return c.UnsubscribeFrom(context.Background(), Subscription{AccountsProposed: accounts})
+}
+
+// SubscribeBooks subscribes to transactions affecting order books. They are
+// delivered on StreamTransaction. If a Book requests a snapshot, the current
+// offers are returned in the response.
+func (c *Client) SubscribeBooks(books []Book) (BaseResponse, error) {
+	return c.SubscribeTo(context.Background(), Subscription{Books: books})
+}
+
+func (c *Client) UnsubscribeBooks(books []Book) (BaseResponse, error) {
+	return c.UnsubscribeFrom(context.Background(), Subscription{Books: books})
+}
+
+// ActiveSubscriptions returns everything the client is currently subscribed
+// to.
+func (c *Client) ActiveSubscriptions() Subscription {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.subscriptions.list()
+}
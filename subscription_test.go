@@ -0,0 +1,38 @@
+package xrpl
+
+import "testing"
+
+func TestSubscriptionsBooks(t *testing.T) {
+	book := Book{
+		TakerGets: BookCurrency{Currency: "XRP"},
+		TakerPays: BookCurrency{Currency: "USD", Issuer: "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B"},
+	}
+	both := book
+	both.Both = true
+	taker := book
+	taker.Taker = "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn"
+	snapshot := book
+	snapshot.Snapshot = true
+
+	s := newSubscriptions(make(map[string]bool))
+	s.add(Subscription{Books: []Book{book, both, taker}})
+	if n := len(s.list().Books); n != 3 {
+		t.Fatalf("%d books subscribed, want 3", n)
+	}
+
+	s.add(Subscription{Books: []Book{snapshot}})
+	if n := len(s.list().Books); n != 3 {
+		t.Errorf("snapshot added a separate subscription: %d books", n)
+	}
+
+	s.remove(Subscription{Books: []Book{both}})
+	books := s.list().Books
+	if len(books) != 2 {
+		t.Fatalf("%d books left, want 2", len(books))
+	}
+	for _, b := range books {
+		if b.Both {
+			t.Errorf("book with Both not removed")
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package xrpl
+
+import "context"
+
+// Transport sends a request to an XRPL node and waits for its response. It is
+// implemented by the websocket Client, ClientPool and the JSON-RPC HTTPClient,
+// so code that only needs request/response semantics can accept any of them.
+//
+// Responses have the same shape regardless of transport: the method's result
+// is found under the "result" key, and error responses are returned as
+// *RPCError.
+type Transport interface {
+	Request(req BaseRequest) (BaseResponse, error)
+	RequestContext(ctx context.Context, req BaseRequest) (BaseResponse, error)
+}
+
+var (
+	_ Transport = (*Client)(nil)
+	_ Transport = (*ClientPool)(nil)
+	_ Transport = (*HTTPClient)(nil)
+)
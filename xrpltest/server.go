@@ -0,0 +1,305 @@
+// Package xrpltest provides an in-process fake rippled websocket server for
+// testing code built on xrpl.Client without a live node.
+//
+// Example usage:
+//
+//	srv := xrpltest.NewServer()
+//	defer srv.Close()
+//	srv.On("account_info", map[string]interface{}{
+//		"account_data": map[string]interface{}{"Account": "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn"},
+//		"validated":    true,
+//	})
+//
+//	client := xrpl.NewClient(xrpl.ClientConfig{URL: srv.URL})
+//	res, err := client.Request(xrpl.BaseRequest{"command": "account_info"})
+package xrpltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/xrpscan/xrpl-go"
+	"github.com/xrpscan/xrpl-go/models"
+)
+
+// Handler answers a request with the value of the response's "result" field.
+// Returning an *xrpl.RPCError sends a rippled error response; any other error
+// is sent as an "internal" error.
+type Handler func(req xrpl.BaseRequest) (interface{}, error)
+
+// Server is a fake rippled websocket server. Commands are answered by handlers
+// registered with Handle, On or OnError. Unknown commands are answered with an
+// unknownCmd error, except subscribe, unsubscribe and ping, which succeed with
+// an empty result by default.
+type Server struct {
+	// Websocket URL of the server, e.g. ws://127.0.0.1:51233
+	URL string
+
+	server       *httptest.Server
+	upgrader     websocket.Upgrader
+	mutex        sync.Mutex
+	handlers     map[string]Handler
+	latency      time.Duration
+	latencies    map[string]time.Duration
+	dropCommands map[string]bool
+	conns        map[*serverConn]bool
+	requests     []xrpl.BaseRequest
+}
+
+type serverConn struct {
+	conn  *websocket.Conn
+	mutex sync.Mutex
+}
+
+func (sc *serverConn) writeJSON(v interface{}) error {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.conn.WriteJSON(v)
+}
+
+// NewServer starts a fake rippled server on a random local port.
+func NewServer() *Server {
+	s := &Server{
+		handlers:     make(map[string]Handler),
+		latencies:    make(map[string]time.Duration),
+		dropCommands: make(map[string]bool),
+		conns:        make(map[*serverConn]bool),
+	}
+	empty := func(xrpl.BaseRequest) (interface{}, error) {
+		return map[string]interface{}{}, nil
+	}
+	s.handlers["subscribe"] = empty
+	s.handlers["unsubscribe"] = empty
+	s.handlers["ping"] = empty
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = "ws" + strings.TrimPrefix(s.server.URL, "http")
+	return s
+}
+
+// Close disconnects all clients and shuts the server down.
+func (s *Server) Close() {
+	s.Disconnect()
+	s.server.Close()
+}
+
+// Handle registers handler for command, replacing any previous handler.
+func (s *Server) Handle(command string, handler Handler) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.handlers[command] = handler
+}
+
+// On scripts the results of command. Each request is answered with the next
+// result in order, and the last result is repeated once the script runs out.
+func (s *Server) On(command string, results ...interface{}) {
+	if len(results) == 0 {
+		results = []interface{}{map[string]interface{}{}}
+	}
+	var mutex sync.Mutex
+	next := 0
+	s.Handle(command, func(xrpl.BaseRequest) (interface{}, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		result := results[next]
+		if next < len(results)-1 {
+			next++
+		}
+		return result, nil
+	})
+}
+
+// OnError answers every request for command with a rippled error response.
+func (s *Server) OnError(command, name string, code int, message string) {
+	s.Handle(command, func(xrpl.BaseRequest) (interface{}, error) {
+		return nil, &xrpl.RPCError{Name: name, Code: code, Message: message}
+	})
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.latency = d
+}
+
+// SetCommandLatency delays responses to command by d, overriding SetLatency.
+func (s *Server) SetCommandLatency(command string, d time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.latencies[command] = d
+}
+
+// DisconnectOn makes the server drop the connection, without a close
+// message, whenever it receives command.
+func (s *Server) DisconnectOn(command string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.dropCommands[command] = true
+}
+
+// Disconnect drops all client connections without a close message, as if the
+// network connection was lost. The server keeps accepting new connections.
+func (s *Server) Disconnect() {
+	s.mutex.Lock()
+	conns := s.conns
+	s.conns = make(map[*serverConn]bool)
+	s.mutex.Unlock()
+
+	for sc := range conns {
+		sc.conn.Close()
+	}
+}
+
+// Connections returns the number of connected clients.
+func (s *Server) Connections() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.conns)
+}
+
+// Requests returns all requests received so far, in order.
+func (s *Server) Requests() []xrpl.BaseRequest {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	requests := make([]xrpl.BaseRequest, len(s.requests))
+	copy(requests, s.requests)
+	return requests
+}
+
+// Emit sends message, encoded as JSON, to every connected client.
+func (s *Server) Emit(message interface{}) error {
+	s.mutex.Lock()
+	conns := make([]*serverConn, 0, len(s.conns))
+	for sc := range s.conns {
+		conns = append(conns, sc)
+	}
+	s.mutex.Unlock()
+
+	for _, sc := range conns {
+		if err := sc.writeJSON(message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EmitLedgerClosed sends a ledger stream message to every connected client.
+func (s *Server) EmitLedgerClosed(ledger models.LedgerStream) error {
+	if ledger.Type == "" {
+		ledger.Type = xrpl.StreamResponseType(xrpl.StreamTypeLedger)
+	}
+	return s.Emit(ledger)
+}
+
+// EmitTransaction sends a transaction stream message to every connected
+// client.
+func (s *Server) EmitTransaction(tx models.TransactionStream) error {
+	if tx.Type == "" {
+		tx.Type = xrpl.StreamResponseType(xrpl.StreamTypeTransaction)
+	}
+	return s.Emit(tx)
+}
+
+// EmitValidation sends a validation stream message to every connected
+// client.
+func (s *Server) EmitValidation(validation models.ValidationStream) error {
+	if validation.Type == "" {
+		validation.Type = xrpl.StreamResponseType(xrpl.StreamTypeValidations)
+	}
+	return s.Emit(validation)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	sc := &serverConn{conn: conn}
+	s.mutex.Lock()
+	s.conns[sc] = true
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		delete(s.conns, sc)
+		s.mutex.Unlock()
+		conn.Close()
+	}()
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req xrpl.BaseRequest
+		if err := json.Unmarshal(message, &req); err != nil {
+			sc.writeJSON(xrpl.BaseResponse{
+				"type":          "response",
+				"status":        "error",
+				"error":         "invalidParams",
+				"error_code":    31,
+				"error_message": "Unable to parse request.",
+			})
+			continue
+		}
+
+		command, _ := req["command"].(string)
+		s.mutex.Lock()
+		s.requests = append(s.requests, req)
+		handler := s.handlers[command]
+		latency, ok := s.latencies[command]
+		if !ok {
+			latency = s.latency
+		}
+		drop := s.dropCommands[command]
+		s.mutex.Unlock()
+
+		if drop {
+			return
+		}
+		go func() {
+			if latency > 0 {
+				time.Sleep(latency)
+			}
+			sc.writeJSON(respond(req, handler))
+		}()
+	}
+}
+
+func respond(req xrpl.BaseRequest, handler Handler) xrpl.BaseResponse {
+	res := xrpl.BaseResponse{
+		"id":   req["id"],
+		"type": "response",
+	}
+	if handler == nil {
+		command, _ := req["command"].(string)
+		handler = func(xrpl.BaseRequest) (interface{}, error) {
+			return nil, &xrpl.RPCError{Name: "unknownCmd", Code: 32, Message: fmt.Sprintf("Unknown method %q.", command)}
+		}
+	}
+
+	result, err := handler(req)
+	if err != nil {
+		rpcErr, ok := err.(*xrpl.RPCError)
+		if !ok {
+			rpcErr = &xrpl.RPCError{Name: "internal", Code: 73, Message: err.Error()}
+		}
+		res["status"] = "error"
+		res["error"] = rpcErr.Name
+		res["error_code"] = rpcErr.Code
+		res["error_message"] = rpcErr.Message
+		res["request"] = req
+		return res
+	}
+
+	res["status"] = "success"
+	res["result"] = result
+	return res
+}
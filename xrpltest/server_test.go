@@ -0,0 +1,206 @@
+package xrpltest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/xrpscan/xrpl-go"
+	"github.com/xrpscan/xrpl-go/models"
+	"github.com/xrpscan/xrpl-go/xrpltest"
+)
+
+func dial(t *testing.T, srv *xrpltest.Server, opts ...xrpl.Option) *xrpl.Client {
+	t.Helper()
+	opts = append([]xrpl.Option{xrpl.WithReconnectPolicy(xrpl.ReconnectPolicy{
+		InitialDelay: 10 * time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+	})}, opts...)
+	client, err := xrpl.Dial(context.Background(), srv.URL, opts...)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func result(res xrpl.BaseResponse) map[string]interface{} {
+	r, _ := res["result"].(map[string]interface{})
+	return r
+}
+
+func TestOn(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+	srv.On("ledger_current",
+		map[string]interface{}{"ledger_current_index": "1"},
+		map[string]interface{}{"ledger_current_index": "2"},
+	)
+	client := dial(t, srv)
+
+	for _, want := range []string{"1", "2", "2"} {
+		res, err := client.Request(xrpl.BaseRequest{"command": "ledger_current"})
+		if err != nil {
+			t.Fatalf("Request: %v", err)
+		}
+		if got := result(res)["ledger_current_index"]; got != want {
+			t.Errorf("ledger_current_index = %v, want %s", got, want)
+		}
+	}
+
+	var commands int
+	for _, req := range srv.Requests() {
+		if req["command"] == "ledger_current" {
+			commands++
+		}
+	}
+	if commands != 3 {
+		t.Errorf("server recorded %d ledger_current requests, want 3", commands)
+	}
+}
+
+func TestOnError(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+	srv.OnError("account_info", "actNotFound", 19, "Account not found.")
+	client := dial(t, srv)
+
+	_, err := client.Request(xrpl.BaseRequest{"command": "account_info", "account": "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn"})
+	if !errors.Is(err, xrpl.ErrActNotFound) {
+		t.Fatalf("err = %v, want actNotFound", err)
+	}
+	var rpcErr *xrpl.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != 19 || rpcErr.Message != "Account not found." {
+		t.Errorf("err = %#v", err)
+	}
+
+	_, err = client.Request(xrpl.BaseRequest{"command": "no_such_command"})
+	if !errors.As(err, &rpcErr) || rpcErr.Name != "unknownCmd" {
+		t.Errorf("unknown command: err = %v, want unknownCmd", err)
+	}
+}
+
+func TestSetCommandLatency(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+	srv.On("fee")
+	srv.On("server_info")
+	srv.SetCommandLatency("fee", 200*time.Millisecond)
+	client := dial(t, srv)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.RequestContext(ctx, xrpl.BaseRequest{"command": "server_info"}); err != nil {
+		t.Fatalf("server_info: %v", err)
+	}
+	_, err := client.RequestContext(ctx, xrpl.BaseRequest{"command": "fee"})
+	if !errors.Is(err, xrpl.ErrRequestTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("fee: err = %v, want ErrRequestTimeout", err)
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+	srv.On("submit")
+	srv.SetCommandLatency("submit", time.Second)
+	client := dial(t, srv)
+
+	if _, err := client.Subscribe([]string{xrpl.StreamTypeLedger}); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := client.Request(xrpl.BaseRequest{"command": "submit"})
+		errs <- err
+	}()
+	waitFor(t, "submit request", func() bool {
+		for _, req := range srv.Requests() {
+			if req["command"] == "submit" {
+				return true
+			}
+		}
+		return false
+	})
+	srv.Disconnect()
+
+	if err := <-errs; !errors.Is(err, xrpl.ErrConnectionLost) {
+		t.Errorf("submit: err = %v, want ErrConnectionLost", err)
+	}
+
+	// The client reconnects and restores its subscription
+	waitFor(t, "reconnect", func() bool {
+		return client.State() == xrpl.StateOpen && srv.Connections() == 1
+	})
+	waitFor(t, "subscription restored", func() bool {
+		var subscribes int
+		for _, req := range srv.Requests() {
+			if req["command"] == "subscribe" {
+				subscribes++
+			}
+		}
+		return subscribes == 2
+	})
+}
+
+func TestEmit(t *testing.T) {
+	srv := xrpltest.NewServer()
+	defer srv.Close()
+	client := dial(t, srv)
+
+	transactions := make(chan models.TransactionStream, 1)
+	client.OnTransaction(func(tx models.TransactionStream) {
+		transactions <- tx
+	})
+
+	if err := srv.EmitLedgerClosed(models.LedgerStream{LedgerIndex: 85000000, LedgerHash: "ABC"}); err != nil {
+		t.Fatalf("EmitLedgerClosed: %v", err)
+	}
+	if err := srv.EmitValidation(models.ValidationStream{LedgerIndex: 85000000}); err != nil {
+		t.Fatalf("EmitValidation: %v", err)
+	}
+	if err := srv.EmitTransaction(models.TransactionStream{Validated: true}); err != nil {
+		t.Fatalf("EmitTransaction: %v", err)
+	}
+	if err := srv.Emit(map[string]interface{}{"type": "unknownStream"}); err != nil {
+		t.Fatalf("Emit: %v", err)
+	}
+
+	timeout := time.After(5 * time.Second)
+	select {
+	case <-client.StreamLedger:
+	case <-timeout:
+		t.Fatal("no ledger stream message")
+	}
+	select {
+	case <-client.StreamValidation:
+	case <-timeout:
+		t.Fatal("no validation stream message")
+	}
+	select {
+	case tx := <-transactions:
+		if !tx.Validated {
+			t.Errorf("transaction not decoded: %+v", tx)
+		}
+	case <-timeout:
+		t.Fatal("no transaction stream message")
+	}
+	select {
+	case <-client.StreamDefault:
+	case <-timeout:
+		t.Fatal("no default stream message")
+	}
+}